@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/cli"
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+var (
+	pruneOlderThanFlag string
+	pruneContextFlag   string
+	pruneAllFlag       bool
+	pruneDryRunFlag    bool
+)
+
+func newArchiveCmd() *cobra.Command {
+	archiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Manage completed tasks",
+		Long: `Manage completed tasks. Completed tasks older than 30 days move out of the
+main data file into cold storage when saving, one file per month under
+archive/ next to todos.json; 'upnext archive prune' and 'upnext clear' move
+them sooner. They still show in the Completed tab, 'upnext stats', 'upnext
+report' and 'upnext list --archived'.
+
+The retention is set in the config file, 0 to keep everything in the main
+file:
+
+  [archive]
+  retention_days = 90`,
+	}
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Move old completed tasks to cold storage",
+		Long: `Move completed tasks to cold storage. Pass --older-than to pick them by
+completion date, or --all to move every completed task.
+
+Example:
+  upnext archive prune --older-than 90d --dry-run
+  upnext archive prune --older-than 2026-01-01 --context .
+  upnext archive prune --all`,
+		Args: cobra.NoArgs,
+		RunE: runArchivePrune,
+	}
+	pruneCmd.Flags().StringVar(&pruneOlderThanFlag, "older-than", "", "Only tasks completed before e.g. 90d, 12w or 2006-01-02")
+	pruneCmd.Flags().StringVar(&pruneContextFlag, "context", "", "Only tasks in this directory and below, or \"global\"")
+	pruneCmd.Flags().BoolVar(&pruneAllFlag, "all", false, "Move every completed task, whenever it was completed")
+	pruneCmd.Flags().BoolVar(&pruneDryRunFlag, "dry-run", false, "List what would be moved without moving it")
+
+	archiveCmd.AddCommand(pruneCmd)
+	return archiveCmd
+}
+
+func runArchivePrune(cmd *cobra.Command, args []string) error {
+	if pruneOlderThanFlag == "" && !pruneAllFlag {
+		return fmt.Errorf("pass --older-than to pick completed tasks by date, or --all to move every one")
+	}
+	if pruneOlderThanFlag != "" && pruneAllFlag {
+		return fmt.Errorf("--older-than and --all can't be used together")
+	}
+
+	s, data, err := openColdStore()
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Time{}
+	if pruneOlderThanFlag != "" {
+		cutoff, err = cli.ParseSince(pruneOlderThanFlag, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --older-than value %q (use e.g. 90d, 12w or 2006-01-02)", pruneOlderThanFlag)
+		}
+	}
+
+	inContext := func(string) bool { return true }
+	if pruneContextFlag != "" {
+		dir, err := pruneContext(pruneContextFlag)
+		if err != nil {
+			return err
+		}
+		inContext = func(ctx string) bool {
+			return ctx == dir || (dir != "" && strings.HasPrefix(ctx, dir+string(filepath.Separator)))
+		}
+	}
+
+	pruned := data.TakeArchived(func(a model.ArchivedTodo) bool {
+		return (cutoff.IsZero() || a.Completed.Before(cutoff)) && inContext(a.Context)
+	})
+	if len(pruned) == 0 {
+		fmt.Println("No completed tasks to move.")
+		return nil
+	}
+
+	if pruneDryRunFlag {
+		fmt.Printf("Would move %d completed %s to cold storage:\n", len(pruned), model.Plural(len(pruned), "task"))
+		for _, item := range pruned {
+			fmt.Printf("  %s  %s\n", item.Completed.Format("2006-01-02"), item.Text)
+		}
+		return nil
+	}
+
+	if err := moveToCold(s, data, pruned); err != nil {
+		return err
+	}
+	fmt.Printf("Moved %d completed %s to %s\n", len(pruned), model.Plural(len(pruned), "task"), segmentNames(s, pruned))
+	return nil
+}
+
+// openColdStore opens the global store for commands that move tasks to
+// cold storage, which only the JSON store has
+func openColdStore() (*store.JSONStore, *model.Data, error) {
+	opened, err := store.OpenGlobal()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize store: %w", err)
+	}
+	s, ok := opened.(*store.JSONStore)
+	if !ok {
+		return nil, nil, fmt.Errorf("cold storage needs the JSON store; set format = \"json\" under [store] in the config file")
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load data: %w", err)
+	}
+	return s, data, nil
+}
+
+// moveToCold saves items, already taken out of data, to cold storage and
+// then saves data. If data can't be saved the main file still has them, so
+// they're taken back out of cold storage.
+func moveToCold(s *store.JSONStore, data *model.Data, items []model.ArchivedTodo) error {
+	if err := s.SaveCold(items); err != nil {
+		return fmt.Errorf("failed to save cold storage: %w", err)
+	}
+	if err := s.Save(data); err != nil {
+		_ = s.DeleteCold(items)
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+	return nil
+}
+
+// segmentNames lists the segment files items went to, like archive/2026-01.jsonl
+func segmentNames(s *store.JSONStore, items []model.ArchivedTodo) string {
+	files := map[string]bool{}
+	for _, item := range items {
+		path := s.SegmentPath(item.Completed)
+		files[filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path))] = true
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// loadHistory adds the completed tasks in cold storage to data, for
+// commands that read the whole history. data must not be saved afterwards.
+func loadHistory(s store.Store, data *model.Data) error {
+	cs, ok := s.(store.ColdStore)
+	if !ok {
+		return nil
+	}
+	cold, err := cs.LoadCold()
+	if err != nil {
+		return fmt.Errorf("failed to load completed tasks: %w", err)
+	}
+	data.Archive = model.MergeArchive(cold, data.Archive)
+	return nil
+}
+
+// pruneContext turns --context into the context to prune: "" for global
+// tasks, otherwise an absolute directory
+func pruneContext(value string) (string, error) {
+	if strings.EqualFold(value, "global") {
+		return "", nil
+	}
+	return filepath.Abs(value)
+}
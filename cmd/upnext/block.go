@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+var (
+	blockOnFlags     []string
+	blockRemoveFlags []string
+)
+
+func newBlockCmd() *cobra.Command {
+	blockCmd := &cobra.Command{
+		Use:   "block <id> --on <id>",
+		Short: "Mark a task as blocked by other tasks",
+		Long: `Mark a task as blocked by other tasks. A blocked task sorts below the
+tasks you can act on, and is unblocked once its blockers are completed.
+
+Each <id> is a task's number in 'upnext list' or (a unique part of) its ID.
+With no flags, the task's blockers and dependents are printed.
+
+Example:
+  upnext block 3 --on 1 --on 2
+  upnext block 3 --remove 2`,
+		Args: cobra.ExactArgs(1),
+		RunE: runBlock,
+	}
+
+	blockCmd.Flags().StringArrayVar(&blockOnFlags, "on", nil, "Task that must be done first (repeatable)")
+	blockCmd.Flags().StringArrayVar(&blockRemoveFlags, "remove", nil, "Blocker to remove (repeatable)")
+
+	return blockCmd
+}
+
+func runBlock(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	i, err := resolveTodo(data, args[0])
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range blockOnFlags {
+		j, err := resolveTodo(data, ref)
+		if err != nil {
+			return err
+		}
+		if err := data.AddBlocker(i, j); err != nil {
+			return err
+		}
+	}
+	for _, ref := range blockRemoveFlags {
+		j, err := resolveTodo(data, ref)
+		if err != nil {
+			return err
+		}
+		data.RemoveBlocker(i, data.Items[j].ID)
+	}
+
+	if len(blockOnFlags) > 0 || len(blockRemoveFlags) > 0 {
+		if err := s.Save(data); err != nil {
+			return fmt.Errorf("failed to save data: %w", err)
+		}
+	}
+
+	todo := data.Items[i]
+	if blockers := data.Blockers(todo); len(blockers) > 0 {
+		fmt.Printf("%s is blocked by: %s\n", todo.Text, model.JoinTexts(blockers))
+	} else {
+		fmt.Printf("%s is not blocked\n", todo.Text)
+	}
+	if dependents := data.Dependents(todo.ID); len(dependents) > 0 {
+		fmt.Printf("  and blocks: %s\n", model.JoinTexts(dependents))
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/config"
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+var (
+	cleanupCompleteFlag bool
+	cleanupDropFlag     bool
+	cleanupUnscopeFlag  bool
+	cleanupYesFlag      bool
+)
+
+func newCleanupCmd() *cobra.Command {
+	cleanupCmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Deal with tasks left on merged or deleted branches",
+		Long: `List the tasks added with 'upnext add --branch' whose branch has since
+been merged into the one checked out, or deleted. They no longer show
+anywhere, so they would otherwise be forgotten. Tasks whose directory is
+gone, or no longer a repository, are listed as unknown. Pass a flag to
+complete them, delete them, or keep them without the branch so they show
+again.
+
+A branch only counts as merged once it has commits of its own: a branch
+made for a task and not worked on yet is still open.
+
+--complete and --drop ask about each task first (y/n, a for all the rest,
+q to stop), unless confirm is off in the [tui] config or --yes is given.
+
+Example:
+  upnext cleanup
+  upnext cleanup --unscope
+  upnext cleanup --drop --yes`,
+		Args: cobra.NoArgs,
+		RunE: runCleanup,
+	}
+
+	cleanupCmd.Flags().BoolVar(&cleanupCompleteFlag, "complete", false, "Complete the leftover tasks")
+	cleanupCmd.Flags().BoolVar(&cleanupDropFlag, "drop", false, "Delete the leftover tasks")
+	cleanupCmd.Flags().BoolVar(&cleanupUnscopeFlag, "unscope", false, "Keep the leftover tasks, showing them on every branch")
+	cleanupCmd.Flags().BoolVarP(&cleanupYesFlag, "yes", "y", false, "Don't ask before completing or dropping each task")
+	cleanupCmd.MarkFlagsMutuallyExclusive("complete", "drop", "unscope")
+
+	return cleanupCmd
+}
+
+func runCleanup(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	cwd, _ := os.Getwd()
+	co := store.CheckoutOf(cwd)
+
+	// Each branch is looked up once per directory and recorded tip
+	type branchKey struct{ dir, branch, tip string }
+	states := map[branchKey]string{}
+	var leftover []model.Todo
+	for _, item := range data.Items {
+		if item.Branch == "" {
+			continue
+		}
+		dir := item.ContextIn(co)
+		key := branchKey{dir, item.Branch, item.BranchTip}
+		state, ok := states[key]
+		if !ok {
+			// A directory that is gone, or no longer a repository, can't be checked
+			var err error
+			if state, err = store.BranchState(dir, item.Branch, item.BranchTip); err != nil {
+				state = store.BranchUnknown
+			}
+			states[key] = state
+		}
+		if state == store.BranchMerged || state == store.BranchDeleted || state == store.BranchUnknown {
+			leftover = append(leftover, item)
+		}
+	}
+
+	if len(leftover) == 0 {
+		fmt.Println("No tasks left on merged, deleted or unknown branches.")
+		return nil
+	}
+
+	stateOf := func(item model.Todo) string {
+		return states[branchKey{item.ContextIn(co), item.Branch, item.BranchTip}]
+	}
+	describe := func(item model.Todo) string {
+		return fmt.Sprintf("%s (%s, %s)  %s", item.Branch, stateOf(item), model.GetContextDisplay(item.ContextIn(co), cwd), item.Text)
+	}
+
+	if !cleanupCompleteFlag && !cleanupDropFlag && !cleanupUnscopeFlag {
+		fmt.Printf("%d %s left on merged, deleted or unknown branches:\n", len(leftover), model.Plural(len(leftover), "task"))
+		for _, item := range leftover {
+			fmt.Printf("  %s\n", describe(item))
+		}
+		fmt.Println("Run with --complete, --drop or --unscope to deal with them.")
+		return nil
+	}
+
+	if (cleanupCompleteFlag || cleanupDropFlag) && !cleanupYesFlag {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if cfg.TUI.Confirm {
+			verb := "Complete"
+			if cleanupDropFlag {
+				verb = "Drop"
+			}
+			leftover = confirmEach(os.Stdin, leftover, func(item model.Todo) string {
+				return fmt.Sprintf("%s %s?", verb, describe(item))
+			})
+		}
+	}
+
+	now := time.Now()
+	for _, item := range leftover {
+		i, err := data.FindTodo(item.ID)
+		if err != nil {
+			continue
+		}
+		switch {
+		case cleanupCompleteFlag:
+			data.Complete(i, now)
+		case cleanupDropFlag:
+			data.Items = append(data.Items[:i], data.Items[i+1:]...)
+			data.Unblock(item.ID)
+		case cleanupUnscopeFlag:
+			data.Items[i].Branch = ""
+		}
+	}
+	if err := s.Save(data); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	if len(leftover) == 0 {
+		fmt.Println("Nothing changed.")
+		return nil
+	}
+
+	verb := "Completed"
+	switch {
+	case cleanupDropFlag:
+		verb = "Dropped"
+	case cleanupUnscopeFlag:
+		verb = "Unscoped"
+	}
+	fmt.Printf("%s %d %s\n", verb, len(leftover), model.Plural(len(leftover), "task"))
+	return nil
+}
+
+// confirmEach asks about each item in turn, returning the ones agreed to:
+// y or n for that item, a for it and all the rest, q to stop asking. Running
+// out of input counts as no.
+func confirmEach(in io.Reader, items []model.Todo, question func(model.Todo) string) []model.Todo {
+	r := bufio.NewReader(in)
+	var agreed []model.Todo
+	for i, item := range items {
+		fmt.Printf("%s [y/n/a/q] ", question(item))
+		line, err := r.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Println()
+			return agreed
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			agreed = append(agreed, item)
+		case "a", "all":
+			return append(agreed, items[i:]...)
+		case "q", "quit":
+			return agreed
+		}
+	}
+	return agreed
+}
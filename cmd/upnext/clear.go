@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/model"
+)
+
+func newClearCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Archive all completed tasks",
+		Long: `Move every completed task out of the main data file into cold storage,
+the same as 'upnext archive prune --all'. They still show in the Completed
+tab, 'upnext stats', 'upnext report' and 'upnext list --archived'.`,
+		Args: cobra.NoArgs,
+		RunE: runClear,
+	}
+}
+
+func runClear(cmd *cobra.Command, args []string) error {
+	s, data, err := openColdStore()
+	if err != nil {
+		return err
+	}
+
+	cleared := data.TakeArchived(func(model.ArchivedTodo) bool { return true })
+	if len(cleared) == 0 {
+		fmt.Println("No completed tasks to clear.")
+		return nil
+	}
+
+	if err := moveToCold(s, data, cleared); err != nil {
+		return err
+	}
+	fmt.Printf("Archived %d completed %s to %s\n", len(cleared), model.Plural(len(cleared), "task"), segmentNames(s, cleared))
+	return nil
+}
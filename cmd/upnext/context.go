@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+func newContextCmd() *cobra.Command {
+	contextCmd := &cobra.Command{
+		Use:   "context",
+		Short: "Manage the directories tasks belong to",
+		Long: `Manage the directories tasks belong to. A task belongs to the directory
+it was added in, by its absolute path, so moving a project leaves its
+tasks behind. 'upnext context relink' moves them after it.
+
+To have tasks follow a git repository wherever it is cloned, anchor them
+to it in the config file:
+
+  [context]
+  anchor = "repo"
+
+Tasks are then tied to the repository's remote (or first commit) and the
+path within it, and show in the same place in every clone or worktree of
+it, wherever that is.`,
+	}
+
+	relinkCmd := &cobra.Command{
+		Use:   "relink <old> <new>",
+		Short: "Move tasks from one directory to another",
+		Long: `Move the tasks in directory <old> and below to the same places under <new>,
+completed ones included, for example after renaming a project.
+
+Example:
+  upnext context relink ~/src/api ~/work/api`,
+		Args: cobra.ExactArgs(2),
+		RunE: runContextRelink,
+	}
+
+	contextCmd.AddCommand(relinkCmd)
+	return contextCmd
+}
+
+func runContextRelink(cmd *cobra.Command, args []string) error {
+	old, err := contextDir(args[0])
+	if err != nil {
+		return err
+	}
+	dir, err := contextDir(args[1])
+	if err != nil {
+		return err
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	// Anchored tasks keep their anchor if <new> is in the same repository
+	var co model.Checkout
+	if repo, ok := store.FindRepo(dir); ok {
+		co = model.Checkout{Repo: repo.ID, Root: repo.Root}
+	}
+
+	n := data.Relink(old, dir, co)
+	var cold []model.ArchivedTodo
+	cs, hasCold := s.(store.ColdStore)
+	if hasCold {
+		items, err := cs.LoadCold()
+		if err != nil {
+			return fmt.Errorf("failed to load completed tasks: %w", err)
+		}
+		for i := range items {
+			if items[i].Relink(old, dir, co) {
+				cold = append(cold, items[i])
+			}
+		}
+		n += len(cold)
+	}
+	if n == 0 {
+		fmt.Printf("No tasks in %s\n", old)
+		return nil
+	}
+
+	if err := s.Save(data); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+	if len(cold) > 0 {
+		if err := cs.SaveCold(cold); err != nil {
+			return fmt.Errorf("failed to save completed tasks: %w", err)
+		}
+	}
+	fmt.Printf("Moved %d %s from %s to %s\n", n, model.Plural(n, "task"), old, dir)
+	return nil
+}
+
+// contextDir turns a directory argument into an absolute path, expanding ~
+func contextDir(value string) (string, error) {
+	if rest, ok := strings.CutPrefix(value, "~"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		value = filepath.Join(home, rest)
+	}
+	return filepath.Abs(value)
+}
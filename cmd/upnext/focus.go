@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/store"
+	"upnext/internal/tui"
+)
+
+var focusAllFlag bool
+
+func newFocusCmd() *cobra.Command {
+	focusCmd := &cobra.Command{
+		Use:   "focus",
+		Short: "Show only the next task",
+		Long: `Open the TUI in focus mode, showing only the top task of the current
+context. Press enter to complete it and move on to the next, s to skip it
+without changing the order, and f to go back to the full list.`,
+		Args: cobra.NoArgs,
+		RunE: runFocus,
+	}
+
+	focusCmd.Flags().BoolVar(&focusAllFlag, "all", false, "Include tasks from every context")
+
+	return focusCmd
+}
+
+func runFocus(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = ""
+	}
+
+	return tui.RunFocus(s, cwd, focusAllFlag)
+}
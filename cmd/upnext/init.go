@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/store"
+)
+
+var initDirFlag bool
+
+func newInitCmd() *cobra.Command {
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Short: "Keep this project's tasks in a file of its own",
+		Long: `Create a task file for the project in the current directory. From here
+and below, tasks added in the project go to that file instead of the
+global one, and the list shows both the project's tasks and the global
+tasks that apply here.
+
+The file is .upnext.json, or .upnext/todos.json with --dir. Contexts in
+it are relative to the project, so it can be committed and shared; see
+'upnext install-merge-driver'. Tasks already in the global file stay
+there.`,
+		Args: cobra.NoArgs,
+		RunE: runInit,
+	}
+
+	initCmd.Flags().BoolVar(&initDirFlag, "dir", false, "Create a .upnext directory instead of a single file")
+
+	return initCmd
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	path, err := store.InitProject(cwd, initDirFlag)
+	if err != nil {
+		return fmt.Errorf("failed to create the project's task file: %w", err)
+	}
+	fmt.Printf("Created %s; tasks added here and below go to it\n", path)
+	return nil
+}
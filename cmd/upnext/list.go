@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/cli"
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+var (
+	listContextFlag  string
+	listAllFlag      bool
+	listArchivedFlag bool
+	listPriorityFlag string
+	listSinceFlag    string
+	listSortFlag     string
+	listUpcomingFlag bool
+	listLimitFlag    int
+	listFormatFlag   string
+	listTagFlag      string
+)
+
+func newListCmd() *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List tasks for scripting, one per line",
+		Long: `List tasks relevant to the current directory, one per line.
+
+Tasks follow the same context rules as the TUI: global tasks, tasks from
+parent directories and tasks from subdirectories are shown.
+
+--format takes a Go text/template evaluated for each task. Available fields:
+  .Index .ID .Text .Description .Priority .Created .Completed
+  .Due .Scheduled .Tags .Repeat .Steps .Progress .Estimate .Position
+  .Context .Path .Done
+
+.Index numbers the lines printed; .Position is the task's place in the whole
+active list, 0-based, the same whatever the filters and --sort (-1 for
+completed tasks).
+
+Example:
+  upnext list --sort priority --format '{{.Priority.Icon}} {{.Text}} ({{.Context}})'`,
+		Args: cobra.NoArgs,
+		RunE: runList,
+	}
+
+	listCmd.Flags().StringVarP(&listContextFlag, "context", "c", "", "Directory to list tasks for (default: current directory)")
+	listCmd.Flags().BoolVar(&listAllFlag, "all", false, "List tasks from every context")
+	listCmd.Flags().BoolVar(&listArchivedFlag, "archived", false, "List completed tasks instead of active ones")
+	listCmd.Flags().StringVarP(&listPriorityFlag, "priority", "p", "", "Only list tasks with this priority: high, medium, or low")
+	listCmd.Flags().StringVarP(&listTagFlag, "tag", "t", "", "Only list tasks with this tag")
+	listCmd.Flags().StringVar(&listSinceFlag, "since", "", "Only list tasks created (or completed) since e.g. 7d, 2w or 2006-01-02")
+	listCmd.Flags().StringVar(&listSortFlag, "sort", cli.SortPosition, "Sort by created, priority, due, or position")
+	listCmd.Flags().BoolVar(&listUpcomingFlag, "upcoming", false, "Include tasks scheduled for later or snoozed")
+	listCmd.Flags().IntVarP(&listLimitFlag, "limit", "n", 0, "Maximum number of tasks to list (0 = unlimited)")
+	listCmd.Flags().StringVarP(&listFormatFlag, "format", "f", "", "Go template for each line (default \""+cli.DefaultListFormat+"\")")
+
+	return listCmd
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+	if listArchivedFlag {
+		if err := loadHistory(s, data); err != nil {
+			return err
+		}
+	}
+
+	cwd := listContextFlag
+	if cwd == "" {
+		cwd, err = os.Getwd()
+		if err != nil {
+			cwd = ""
+		}
+	} else if abs, err := filepath.Abs(cwd); err == nil {
+		cwd = abs
+	}
+
+	opts := cli.ListOptions{
+		Filter: cli.Filter{
+			Cwd:      cwd,
+			Checkout: store.CheckoutOf(cwd),
+			All:      listAllFlag,
+			Upcoming: listUpcomingFlag,
+			Tag:      listTagFlag,
+		},
+		Archived: listArchivedFlag,
+		Sort:     listSortFlag,
+		Limit:    listLimitFlag,
+		Format:   listFormatFlag,
+	}
+
+	if listPriorityFlag != "" {
+		priority, err := model.ParsePriority(listPriorityFlag)
+		if err != nil {
+			return err
+		}
+		opts.Priority = &priority
+	}
+
+	opts.Since, err = cli.ParseSince(listSinceFlag, time.Now())
+	if err != nil {
+		return err
+	}
+
+	output, err := cli.RenderList(data, opts)
+	if err != nil {
+		return err
+	}
+	if output != "" {
+		fmt.Println(output)
+	}
+	return nil
+}
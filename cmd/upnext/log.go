@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+func newLogCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "log <id>",
+		Short: "Show the history of a task",
+		Long: `Show every change logged for a task, oldest first: when it was added,
+edited, moved, completed or deleted.
+
+<id> is a task's number in 'upnext list' or (a unique part of) its ID,
+including the IDs of completed tasks. The history is only kept by the
+event log store, set in the config file:
+
+  [store]
+  format = "events"`,
+		Args: cobra.ExactArgs(1),
+		RunE: runLog,
+	}
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	opened, err := store.OpenGlobal()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	s, ok := opened.(*store.EventStore)
+	if !ok {
+		return fmt.Errorf("task history needs the event log store; set format = \"events\" under [store] in the config file")
+	}
+
+	// Task numbers follow 'upnext list', which includes project tasks
+	current, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	data, err := current.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	id, text, err := resolveLogged(data, args[0])
+	if err != nil {
+		return err
+	}
+	history, err := s.History(id)
+	if err != nil {
+		return fmt.Errorf("failed to read the event log: %w", err)
+	}
+
+	fmt.Println(text)
+	if len(history) == 0 {
+		fmt.Println("  No changes logged.")
+		return nil
+	}
+	for _, e := range history {
+		fmt.Printf("  %s  %s\n", e.Time.Local().Format("2006-01-02 15:04"), describeEvent(e))
+	}
+	return nil
+}
+
+// resolveLogged finds the active or completed task ref refers to, and
+// returns its ID and text
+func resolveLogged(data *model.Data, ref string) (string, string, error) {
+	i, err := resolveTodo(data, ref)
+	if err == nil {
+		return data.Items[i].ID, data.Items[i].Text, nil
+	}
+	var matches []model.ArchivedTodo
+	for _, item := range data.Archive {
+		if item.ID == ref {
+			return item.ID, item.Text, nil
+		}
+		if strings.HasPrefix(item.ID, ref) || strings.HasSuffix(item.ID, ref) {
+			matches = append(matches, item)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", "", err
+	case 1:
+		return matches[0].ID, matches[0].Text, nil
+	}
+	return "", "", fmt.Errorf("task ID %q is ambiguous", ref)
+}
+
+// describeEvent returns one line saying what an event did to its task
+func describeEvent(e store.Event) string {
+	switch e.Type {
+	case store.EventEdited:
+		return "edited " + strings.Join(e.Fields, ", ")
+	case store.EventBumped:
+		return "bumped to top"
+	case store.EventMoved:
+		for i, id := range e.Order {
+			if id == e.ID {
+				return fmt.Sprintf("moved to #%d", i+1)
+			}
+		}
+	}
+	return e.Type
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -14,12 +15,20 @@ import (
 )
 
 var (
-	plainFlag   bool
-	jsonFlag    bool
-	globalFlag  bool
-	allFlag     bool
-	priorityStr string
-	descFlag    string
+	plainFlag    bool
+	jsonFlag     bool
+	globalFlag   bool
+	allFlag      bool
+	archivedFlag bool
+	priorityStr  string
+	descFlag     string
+	dueFlag      string
+	schedFlag    string
+	literalFlag  bool
+	tagFlags     []string
+	repeatFlag   string
+	estFlag      string
+	branchFlag   bool
 )
 
 func main() {
@@ -33,6 +42,7 @@ func main() {
 	rootCmd.Flags().BoolVar(&plainFlag, "plain", false, "Output in plain text format")
 	rootCmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
 	rootCmd.Flags().BoolVar(&allFlag, "all", false, "Show all tasks regardless of context")
+	rootCmd.Flags().BoolVar(&archivedFlag, "archived", false, "Include completed tasks in --plain/--json output")
 
 	addCmd := &cobra.Command{
 		Use:   "add [task]",
@@ -41,7 +51,17 @@ func main() {
 current working directory, so it will only appear when you're in that
 directory or its subdirectories.
 
-Use --global to create a task visible from anywhere.`,
+Use --global to create a task visible from anywhere.
+
+Inline tokens in the task text set other fields:
+  !high !med !low, !!! !! !   priority
+  #tag                        tag
+  @global                     same as --global
+  due:fri, due:next-week      due date
+  -- text                     description
+
+Prefix a token with a backslash to keep it literally (\#123), or pass
+--literal to disable parsing entirely.`,
 		Args: cobra.ExactArgs(1),
 		RunE: runAdd,
 	}
@@ -49,8 +69,36 @@ Use --global to create a task visible from anywhere.`,
 	addCmd.Flags().BoolVarP(&globalFlag, "global", "g", false, "Create a global task (visible from anywhere)")
 	addCmd.Flags().StringVarP(&priorityStr, "priority", "p", "medium", "Priority: high, medium, or low")
 	addCmd.Flags().StringVarP(&descFlag, "desc", "d", "", "Task description")
+	addCmd.Flags().StringVar(&dueFlag, "due", "", "Due date, e.g. fri, tomorrow, next week, in 3d, eom, 2006-01-02")
+	addCmd.Flags().StringVarP(&repeatFlag, "repeat", "r", "", "Repeat rule: daily, weekdays, weekly:mon,thu, monthly:15, after:3d")
+	addCmd.Flags().StringArrayVarP(&tagFlags, "tag", "t", nil, "Tag the task (repeatable)")
+	addCmd.Flags().BoolVar(&literalFlag, "literal", false, "Don't parse quick-add tokens from the task text")
+	addCmd.Flags().StringVar(&schedFlag, "scheduled", "", "Hide the task until this date (same formats as --due)")
+	addCmd.Flags().StringVar(&estFlag, "est", "", "Estimate as time (30m, 1h30m) or effort points (3pt)")
+	addCmd.Flags().BoolVarP(&branchFlag, "branch", "b", false, "Only show the task while the current git branch is checked out")
 
 	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(newListCmd())
+	rootCmd.AddCommand(newTagCmd())
+	rootCmd.AddCommand(newStatsCmd())
+	rootCmd.AddCommand(newSnoozeCmd())
+	rootCmd.AddCommand(newSubCmd())
+	rootCmd.AddCommand(newBlockCmd())
+	rootCmd.AddCommand(newMoveCmd())
+	rootCmd.AddCommand(newFocusCmd())
+	rootCmd.AddCommand(newTimerCmd())
+	rootCmd.AddCommand(newStartCmd())
+	rootCmd.AddCommand(newStopCmd())
+	rootCmd.AddCommand(newReportCmd())
+	rootCmd.AddCommand(newArchiveCmd())
+	rootCmd.AddCommand(newClearCmd())
+	rootCmd.AddCommand(newLogCmd())
+	rootCmd.AddCommand(newSyncCmd())
+	rootCmd.AddCommand(newMergeDriverCmd())
+	rootCmd.AddCommand(newInstallMergeDriverCmd())
+	rootCmd.AddCommand(newInitCmd())
+	rootCmd.AddCommand(newContextCmd())
+	rootCmd.AddCommand(newCleanupCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -59,7 +107,7 @@ Use --global to create a task visible from anywhere.`,
 }
 
 func runRoot(cmd *cobra.Command, args []string) error {
-	s, err := store.NewJSONStore()
+	s, err := store.Open()
 	if err != nil {
 		return fmt.Errorf("failed to initialize store: %w", err)
 	}
@@ -76,15 +124,21 @@ func runRoot(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to load data: %w", err)
 		}
+		if archivedFlag {
+			if err := loadHistory(s, data); err != nil {
+				return err
+			}
+		}
 
+		filter := cli.Filter{Cwd: cwd, Checkout: store.CheckoutOf(cwd), All: allFlag}
 		if jsonFlag {
-			output, err := cli.RenderJSON(data)
+			output, err := cli.RenderJSON(data, filter, archivedFlag)
 			if err != nil {
 				return fmt.Errorf("failed to render JSON: %w", err)
 			}
 			fmt.Println(output)
 		} else {
-			fmt.Println(cli.RenderPlain(data))
+			fmt.Println(cli.RenderPlain(data, filter, archivedFlag))
 		}
 		return nil
 	}
@@ -94,7 +148,7 @@ func runRoot(cmd *cobra.Command, args []string) error {
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
-	s, err := store.NewJSONStore()
+	s, err := store.Open()
 	if err != nil {
 		return fmt.Errorf("failed to initialize store: %w", err)
 	}
@@ -104,33 +158,96 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load data: %w", err)
 	}
 
+	// Pull quick-add tokens (!high, #tag, @global, due:fri, -- desc) out of the text
+	now := time.Now()
+	quick := model.QuickAdd{Text: args[0]}
+	if !literalFlag {
+		quick, err = model.ParseQuickAdd(args[0], now)
+		if err != nil {
+			return err
+		}
+	}
+	if quick.Text == "" {
+		return fmt.Errorf("empty task text")
+	}
+	global := globalFlag || quick.Global
+
 	// Get context (working directory) unless --global is set
 	var context string
-	if !globalFlag {
+	if !global {
 		context, err = os.Getwd()
 		if err != nil {
 			context = ""
 		}
 	}
 
-	// Parse priority
+	// Parse priority; an explicit flag wins over inline tokens
 	priority := model.PriorityMedium
-	switch priorityStr {
-	case "high", "h":
-		priority = model.PriorityHigh
-	case "low", "l":
-		priority = model.PriorityLow
+	if cmd.Flags().Changed("priority") {
+		if priority, err = model.ParsePriority(priorityStr); err != nil {
+			return err
+		}
+	} else if quick.Priority != nil {
+		priority = *quick.Priority
+	}
+
+	description := quick.Description
+	if descFlag != "" {
+		description = descFlag
 	}
 
 	// Create new todo at position 0
 	todo := model.Todo{
 		ID:          model.GenerateID(),
-		Text:        args[0],
-		Description: descFlag,
+		Text:        quick.Text,
+		Description: description,
 		Priority:    priority,
-		Created:     time.Now(),
+		Created:     now,
 		Position:    0,
 		Context:     context,
+		Due:         quick.Due,
+		Tags:        model.AddTags(quick.Tags, tagFlags...),
+	}
+
+	if dueFlag != "" {
+		due, err := model.ParseDate(dueFlag, now)
+		if err != nil {
+			return err
+		}
+		todo.Due = &due
+	}
+	var repeat model.Recurrence
+	if repeatFlag != "" {
+		repeat, err = model.ParseRecurrence(repeatFlag)
+		if err != nil {
+			return err
+		}
+		todo.Repeat = strings.ToLower(strings.TrimSpace(repeatFlag))
+	}
+	if schedFlag != "" {
+		scheduled, err := model.ParseDate(schedFlag, now)
+		if err != nil {
+			return err
+		}
+		todo.Scheduled = &scheduled
+	}
+	if estFlag != "" {
+		est, err := model.ParseEstimate(estFlag)
+		if err != nil {
+			return err
+		}
+		todo.Estimate = est.String()
+	}
+	if branchFlag {
+		if global {
+			return fmt.Errorf("--branch can't be used with --global")
+		}
+		todo.Branch = model.CurrentBranch(context)
+		if todo.Branch == "" {
+			return fmt.Errorf("--branch needs a git branch checked out here")
+		}
+		// A branch with no commits yet has no tip to record
+		todo.BranchTip, _ = store.BranchTip(context, todo.Branch)
 	}
 
 	// Shift existing items
@@ -146,9 +263,27 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	location := "here"
-	if globalFlag {
+	if global {
 		location = "globally"
 	}
-	fmt.Printf("Added %s: %s\n", location, args[0])
+	fmt.Printf("Added %s: %s\n", location, todo.Text)
+	if len(todo.Tags) > 0 {
+		fmt.Printf("  tags #%s\n", strings.Join(todo.Tags, " #"))
+	}
+	if todo.Due != nil {
+		fmt.Printf("  due %s\n", todo.Due.Format("Mon Jan 2"))
+	}
+	if todo.Repeat != "" {
+		fmt.Printf("  repeats %s\n", repeat)
+	}
+	if todo.Estimate != "" {
+		fmt.Printf("  estimated %s\n", todo.Estimate)
+	}
+	if todo.IsScheduledLater(now) {
+		fmt.Printf("  hidden until %s\n", todo.Scheduled.Format("Mon Jan 2"))
+	}
+	if todo.Branch != "" {
+		fmt.Printf("  only on branch %s\n", todo.Branch)
+	}
 	return nil
 }
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+var installPatternFlags []string
+
+func newMergeDriverCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge-driver <base> <ours> <theirs>",
+		Short: "Merge two versions of a task file, for git",
+		Long: `Merge two versions of a task file that both changed <base>, writing the
+result to <ours>. Git runs this with %O %A %B once it is set up with
+'upnext install-merge-driver'.
+
+Tasks are merged by ID: tasks added on either side are kept, completing a
+task wins over it staying active, and a change made on one side only is
+taken. A task deleted on one side and edited on the other is kept, and
+where both sides changed the same field of a task, the edit made last is
+kept, ours when that can't be told; those clashes are listed.`,
+		Args:   cobra.ExactArgs(3),
+		Hidden: true,
+		RunE:   runMergeDriver,
+	}
+}
+
+func newInstallMergeDriverCmd() *cobra.Command {
+	installCmd := &cobra.Command{
+		Use:   "install-merge-driver",
+		Short: "Have git merge task files in this repository task by task",
+		Long: `Set up the git repository in the current directory to merge task files
+with 'upnext merge-driver' instead of as text: the driver goes in the
+repository's git config, and the files it is for in .gitattributes,
+which should be committed so everyone's clones use it. By default those
+are the synced data file, todos.json, and the project task files,
+.upnext.json and .upnext/todos.json.
+
+The git config isn't shared, so each clone runs this once.
+
+Example:
+  upnext install-merge-driver
+  upnext install-merge-driver --pattern 'tasks/*.json'`,
+		Args: cobra.NoArgs,
+		RunE: runInstallMergeDriver,
+	}
+
+	installCmd.Flags().StringArrayVar(&installPatternFlags, "pattern", []string{"todos.json", store.ProjectFile, store.ProjectDir + "/todos.json"},
+		"Files to merge, as a .gitattributes pattern (repeatable)")
+
+	return installCmd
+}
+
+func runMergeDriver(cmd *cobra.Command, args []string) error {
+	conflicts, err := store.MergeFiles(args[0], args[1], args[2])
+	if err != nil {
+		return fmt.Errorf("failed to merge tasks: %w", err)
+	}
+	for _, c := range conflicts {
+		fmt.Fprintln(os.Stderr, "upnext: "+describeConflict(c, "ours", "theirs"))
+	}
+	return nil
+}
+
+func runInstallMergeDriver(cmd *cobra.Command, args []string) error {
+	root, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return fmt.Errorf("not in a git repository: %w", err)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "upnext"
+	}
+	if strings.ContainsAny(exe, " '") {
+		exe = "'" + strings.ReplaceAll(exe, "'", `'\''`) + "'"
+	}
+	for _, kv := range [][2]string{
+		{"merge.upnext.name", "upnext task merge"},
+		{"merge.upnext.driver", exe + " merge-driver %O %A %B"},
+	} {
+		if _, err := gitOutput("config", kv[0], kv[1]); err != nil {
+			return fmt.Errorf("failed to set git config: %w", err)
+		}
+	}
+
+	path := filepath.Join(root, ".gitattributes")
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	have := map[string]bool{}
+	for _, l := range strings.Split(string(existing), "\n") {
+		have[strings.TrimSpace(l)] = true
+	}
+	var added []string
+	for _, pattern := range installPatternFlags {
+		line := pattern + " merge=upnext"
+		if have[line] {
+			continue
+		}
+		if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+			existing = append(existing, '\n')
+		}
+		existing = append(existing, line+"\n"...)
+		have[line] = true
+		added = append(added, pattern)
+	}
+	if len(added) == 0 {
+		fmt.Printf("Merge driver set up; %s already lists %s\n", path, strings.Join(installPatternFlags, ", "))
+		return nil
+	}
+	if err := os.WriteFile(path, existing, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Merge driver set up for %s; commit %s to share it\n", strings.Join(added, ", "), path)
+	return nil
+}
+
+// describeConflict says how a clash between two sides' changes to a task
+// was resolved
+func describeConflict(c model.Conflict, ours, theirs string) string {
+	kept := ours
+	if c.Kept == model.Theirs {
+		kept = theirs
+	}
+	if c.Field == "status" {
+		return fmt.Sprintf("%q was completed or deleted on one side and changed on the other, kept %s", c.Text, kept)
+	}
+	return fmt.Sprintf("%q: both sides changed %s, kept %s", c.Text, c.Field, kept)
+}
+
+// gitOutput runs git in the current directory and returns its output,
+// trimmed
+func gitOutput(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
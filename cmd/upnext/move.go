@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/cli"
+	"upnext/internal/store"
+)
+
+var (
+	moveToFlag     int
+	moveBeforeFlag string
+	moveAfterFlag  string
+)
+
+func newMoveCmd() *cobra.Command {
+	moveCmd := &cobra.Command{
+		Use:   "move <id> --to <n> | --before <id> | --after <id>",
+		Short: "Move a task to another place in the list",
+		Long: `Move a task to another place in the list. --to puts it at that row of
+'upnext list' for the current directory; --before and --after put it
+next to another task.
+
+Each <id> is a task's number in 'upnext list' or (a unique part of) its ID.
+
+Example:
+  upnext move 5 --to 2
+  upnext move 5 --after 1`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMove,
+	}
+
+	moveCmd.Flags().IntVar(&moveToFlag, "to", 0, "Row in 'upnext list' to move the task to")
+	moveCmd.Flags().StringVar(&moveBeforeFlag, "before", "", "Task to move it in front of")
+	moveCmd.Flags().StringVar(&moveAfterFlag, "after", "", "Task to move it behind")
+	moveCmd.MarkFlagsMutuallyExclusive("to", "before", "after")
+	moveCmd.MarkFlagsOneRequired("to", "before", "after")
+
+	return moveCmd
+}
+
+func runMove(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	i, err := resolveTodo(data, args[0])
+	if err != nil {
+		return err
+	}
+	todo := data.Items[i]
+	cwd, _ := os.Getwd()
+
+	var j int
+	after := moveAfterFlag != ""
+	switch {
+	case cmd.Flags().Changed("to"):
+		items := cli.Filter{Cwd: cwd, Checkout: store.CheckoutOf(cwd)}.Items(data)
+		if moveToFlag < 1 || moveToFlag > len(items) {
+			return fmt.Errorf("--to must be between 1 and %d", len(items))
+		}
+		if j, err = data.FindTodo(items[moveToFlag-1].ID); err != nil {
+			return err
+		}
+		// Moving down lands behind the task on that row, so the moved
+		// task ends up on it
+		after = i < j
+	case after:
+		if j, err = resolveTodo(data, moveAfterFlag); err != nil {
+			return err
+		}
+	default:
+		if j, err = resolveTodo(data, moveBeforeFlag); err != nil {
+			return err
+		}
+	}
+
+	data.MoveTodo(i, j, after)
+	if err := s.Save(data); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	items := cli.Filter{Cwd: cwd, Checkout: store.CheckoutOf(cwd)}.Items(data)
+	for n, item := range items {
+		if item.ID == todo.ID {
+			fmt.Printf("Moved to #%d: %s\n", n+1, todo.Text)
+			return nil
+		}
+	}
+	fmt.Printf("Moved: %s\n", todo.Text)
+	return nil
+}
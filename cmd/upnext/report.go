@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/cli"
+	"upnext/internal/store"
+)
+
+var (
+	reportSinceFlag string
+	reportAllFlag   bool
+)
+
+func newReportCmd() *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Summarize tracked time by context and tag",
+		Long: `Summarize the time tracked with 'upnext start' by context and tag,
+including completed tasks. A task with several tags counts toward each.
+
+Example:
+  upnext report --since monday`,
+		Args: cobra.NoArgs,
+		RunE: runReport,
+	}
+
+	reportCmd.Flags().StringVar(&reportSinceFlag, "since", "", "Only count time since e.g. monday, yesterday, 7d or 2006-01-02")
+	reportCmd.Flags().BoolVar(&reportAllFlag, "all", false, "Include tasks from every context")
+
+	return reportCmd
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+	if err := loadHistory(s, data); err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = ""
+	}
+
+	now := time.Now()
+	since, err := cli.ParseSince(reportSinceFlag, now)
+	if err != nil {
+		return err
+	}
+
+	report := cli.BuildReport(data, cli.Filter{Cwd: cwd, Checkout: store.CheckoutOf(cwd), All: reportAllFlag}, since, now)
+	fmt.Println(cli.RenderReport(report))
+	return nil
+}
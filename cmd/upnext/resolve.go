@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"upnext/internal/cli"
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+// resolveTodo finds the task a command argument refers to and returns its
+// index in data.Items. A small number is the task's row in `upnext list`
+// for the current directory; anything else is matched against task IDs.
+func resolveTodo(data *model.Data, ref string) (int, error) {
+	return resolveIn(data, ref, false)
+}
+
+// resolveUpcoming is resolveTodo for commands on hidden tasks, where a
+// number is the task's row in `upnext list --upcoming`
+func resolveUpcoming(data *model.Data, ref string) (int, error) {
+	return resolveIn(data, ref, true)
+}
+
+func resolveIn(data *model.Data, ref string, upcoming bool) (int, error) {
+	if n, err := strconv.Atoi(ref); err == nil && n > 0 && !strings.Contains(ref, ".") && len(ref) <= 4 {
+		cwd, _ := os.Getwd()
+		items := cli.Filter{Cwd: cwd, Checkout: store.CheckoutOf(cwd), Upcoming: upcoming}.Items(data)
+		if n > len(items) {
+			return -1, fmt.Errorf("no task #%d here (%d tasks)", n, len(items))
+		}
+		return data.FindTodo(items[n-1].ID)
+	}
+	return data.FindTodo(ref)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+func newSnoozeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "snooze <id> <when>",
+		Short: "Hide a task until a later time",
+		Long: `Hide a task until a later time. When it wakes, the task moves back to the
+top of the list.
+
+<when> is a duration from now (45m, 2h, 1h30m) or a day (tomorrow, mon,
+next week, in 3d, 2006-01-02), which wakes at the start of that day.
+Use "off" to wake a snoozed task now.
+
+<id> is the task's number in 'upnext list' or (a unique part of) its ID.
+With "off" the number is the task's row in 'upnext list --upcoming',
+which also lists snoozed tasks.
+
+Example:
+  upnext snooze 2 tomorrow
+  upnext list --upcoming
+  upnext snooze 3 off`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: runSnooze,
+	}
+}
+
+func runSnooze(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	when := strings.Join(args[1:], " ")
+	wake := strings.EqualFold(when, "off") || strings.EqualFold(when, "now")
+	resolve := resolveTodo
+	if wake {
+		resolve = resolveUpcoming
+	}
+	i, err := resolve(data, args[0])
+	if err != nil {
+		return err
+	}
+	todo := &data.Items[i]
+
+	now := time.Now()
+	if wake {
+		if todo.SnoozedUntil == nil {
+			return fmt.Errorf("%s is not snoozed", todo.Text)
+		}
+		// Expire the snooze so it wakes like any other, at the top of the list
+		text := todo.Text
+		todo.SnoozedUntil = &now
+		data.WakeSnoozed(now)
+		if err := s.Save(data); err != nil {
+			return fmt.Errorf("failed to save data: %w", err)
+		}
+		fmt.Printf("Woke: %s\n", text)
+		return nil
+	}
+
+	until, err := model.ParseWhen(when, now)
+	if err != nil {
+		return err
+	}
+	if !until.After(now) {
+		return fmt.Errorf("%s is not in the future", until.Format("Mon Jan 2 15:04"))
+	}
+	todo.SnoozedUntil = &until
+
+	if err := s.Save(data); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+	fmt.Printf("Snoozed until %s: %s\n", until.Format("Mon Jan 2 15:04"), todo.Text)
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/cli"
+	"upnext/internal/store"
+)
+
+var statsAllFlag bool
+
+func newStatsCmd() *cobra.Command {
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show completion stats for the current context",
+		Args:  cobra.NoArgs,
+		RunE:  runStats,
+	}
+
+	statsCmd.Flags().BoolVar(&statsAllFlag, "all", false, "Include tasks from every context")
+
+	return statsCmd
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+	if err := loadHistory(s, data); err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = ""
+	}
+
+	fmt.Println(cli.RenderStats(data, cli.Filter{Cwd: cwd, Checkout: store.CheckoutOf(cwd), All: statsAllFlag}, time.Now()))
+	return nil
+}
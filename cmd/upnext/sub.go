@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+func newSubCmd() *cobra.Command {
+	subCmd := &cobra.Command{
+		Use:   "sub",
+		Short: "Manage a task's checklist of steps",
+		Long: `Manage a task's checklist of steps.
+
+<id> is the task's number in 'upnext list' or (a unique part of) its ID.
+Steps are numbered from 1 in the order they were added.
+
+Example:
+  upnext sub add 1 write migration
+  upnext sub done 1 1 2`,
+	}
+
+	subCmd.AddCommand(&cobra.Command{
+		Use:   "add <id> <step text>",
+		Short: "Add a step to the end of a task's checklist",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateSteps(args[0], func(todo *model.Todo) error {
+				return todo.AddStep(strings.Join(args[1:], " "))
+			})
+		},
+	})
+
+	subCmd.AddCommand(&cobra.Command{
+		Use:   "done <id> <step>...",
+		Short: "Tick off steps by number",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateSteps(args[0], func(todo *model.Todo) error {
+				for _, arg := range args[1:] {
+					n, err := strconv.Atoi(arg)
+					if err != nil || n < 1 || n > len(todo.Steps) {
+						return fmt.Errorf("no step %s (%d steps)", arg, len(todo.Steps))
+					}
+					todo.Steps[n-1].Done = true
+				}
+				return nil
+			})
+		},
+	})
+
+	subCmd.AddCommand(&cobra.Command{
+		Use:   "list <id>",
+		Short: "Show a task's checklist",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateSteps(args[0], nil)
+		},
+	})
+
+	return subCmd
+}
+
+// updateSteps applies change to the referenced task, saves, and prints its
+// checklist. A nil change only prints.
+func updateSteps(ref string, change func(*model.Todo) error) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	i, err := resolveTodo(data, ref)
+	if err != nil {
+		return err
+	}
+	todo := &data.Items[i]
+
+	if change != nil {
+		if err := change(todo); err != nil {
+			return err
+		}
+		if err := s.Save(data); err != nil {
+			return fmt.Errorf("failed to save data: %w", err)
+		}
+	}
+
+	if len(todo.Steps) == 0 {
+		fmt.Printf("%s: no steps\n", todo.Text)
+		return nil
+	}
+	fmt.Printf("%s (%s)\n", todo.Text, model.StepSummary(todo.Steps))
+	for n, step := range todo.Steps {
+		mark := " "
+		if step.Done {
+			mark = "x"
+		}
+		fmt.Printf("  %d. [%s] %s\n", n+1, mark, step.Text)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/store"
+)
+
+func newSyncCmd() *cobra.Command {
+	syncCmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync tasks with other machines through git",
+		Long: `Commit local changes to the data directory's git repository, pull in the
+remote's and push the result.
+
+Changes from both sides are merged task by task rather than as text: tasks
+added on either side are kept, completing a task wins over it staying
+active, and a task deleted on one side but edited on the other is kept.
+Where both sides edited the same field of a task, the edit made last
+wins, whenever each side synced; tasks record when each of their fields
+was changed. Those clashes are listed.
+
+Set up syncing once on each machine with 'upnext sync init'.`,
+		Args: cobra.NoArgs,
+		RunE: runSync,
+	}
+
+	initCmd := &cobra.Command{
+		Use:   "init <remote>",
+		Short: "Set up syncing with a git remote",
+		Long: `Make the data directory a git repository that syncs with <remote>, then
+sync. <remote> is anything git can push to: a URL, or the path of a
+repository such as a bare one on a shared drive.
+
+Example:
+  upnext sync init git@github.com:me/tasks.git
+  git init --bare ~/Dropbox/tasks.git && upnext sync init ~/Dropbox/tasks.git`,
+		Args: cobra.ExactArgs(1),
+		RunE: runSyncInit,
+	}
+
+	syncCmd.AddCommand(initCmd)
+	return syncCmd
+}
+
+func runSyncInit(cmd *cobra.Command, args []string) error {
+	s, err := syncStore()
+	if err != nil {
+		return err
+	}
+	if err := s.InitSync(args[0]); err != nil {
+		return fmt.Errorf("failed to set up syncing: %w", err)
+	}
+	fmt.Printf("Syncing %s with %s\n", s.Dir(), args[0])
+	return runSyncOnce(s)
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	s, err := syncStore()
+	if err != nil {
+		return err
+	}
+	return runSyncOnce(s)
+}
+
+// syncStore opens the store for syncing, which only the JSON store supports
+func syncStore() (*store.JSONStore, error) {
+	opened, err := store.OpenGlobal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize store: %w", err)
+	}
+	s, ok := opened.(*store.JSONStore)
+	if !ok {
+		return nil, fmt.Errorf("syncing needs the JSON store; set format = \"json\" under [store] in the config file")
+	}
+	return s, nil
+}
+
+// runSyncOnce syncs s and reports what it did
+func runSyncOnce(s *store.JSONStore) error {
+	result, err := s.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync: %w", err)
+	}
+
+	var did []string
+	if result.Committed {
+		did = append(did, "committed local changes")
+	}
+	if result.Pulled {
+		did = append(did, "pulled remote changes")
+	}
+	if result.Pushed {
+		did = append(did, "pushed")
+	}
+	if len(did) == 0 {
+		fmt.Println("Already in sync.")
+	} else {
+		fmt.Printf("Synced: %s.\n", strings.Join(did, ", "))
+	}
+
+	for _, c := range result.Conflicts {
+		fmt.Println("  " + describeConflict(c, "this machine's", "the remote's"))
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+func newTagCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tag <id> [+tag|-tag]...",
+		Short: "Add or remove tags on a task",
+		Long: `Add or remove tags on a task. +tag (or a bare tag) adds it, -tag removes it.
+With no changes, the task's current tags are printed.
+
+<id> is the task's number in 'upnext list' or (a unique part of) its ID.
+
+Example:
+  upnext tag 2 +backend -frontend`,
+		// Flag parsing is off so "-tag" isn't mistaken for a flag
+		DisableFlagParsing: true,
+		RunE:               runTag,
+	}
+}
+
+func runTag(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		return cmd.Help()
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	i, err := resolveTodo(data, args[0])
+	if err != nil {
+		return err
+	}
+	todo := &data.Items[i]
+
+	if len(args) > 1 {
+		for _, arg := range args[1:] {
+			if strings.HasPrefix(arg, "-") {
+				todo.Tags = model.RemoveTags(todo.Tags, arg[1:])
+			} else {
+				todo.Tags = model.AddTags(todo.Tags, strings.TrimPrefix(arg, "+"))
+			}
+		}
+		if err := s.Save(data); err != nil {
+			return fmt.Errorf("failed to save data: %w", err)
+		}
+	}
+
+	if len(todo.Tags) == 0 {
+		fmt.Printf("%s: no tags\n", todo.Text)
+	} else {
+		fmt.Printf("%s: #%s\n", todo.Text, strings.Join(todo.Tags, " #"))
+	}
+	return nil
+}
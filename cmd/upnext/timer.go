@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/config"
+	"upnext/internal/store"
+	"upnext/internal/tui"
+)
+
+func newTimerCmd() *cobra.Command {
+	timerCmd := &cobra.Command{
+		Use:   "timer",
+		Short: "Time work sessions on a task",
+		Long: `Time work sessions on a task, pomodoro style. Each finished work session
+is recorded on the task and counted in 'upnext stats'.
+
+Session lengths come from the [timer] section of the config file, 25
+minutes of work and a 5 minute break by default:
+
+  [timer]
+  work = "50m"
+  break = "10m"`,
+	}
+
+	startCmd := &cobra.Command{
+		Use:   "start <id>",
+		Short: "Count down a work session on a task, then a break",
+		Long: `Count down a work session on a task, then a break. The terminal bell
+rings when each ends. Press q to stop early; an unfinished session is not
+recorded.
+
+<id> is the task's number in 'upnext list' or (a unique part of) its ID.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTimerStart,
+	}
+	startCmd.Flags().Duration("work", 0, "Length of the work session (default from config)")
+	startCmd.Flags().Duration("break", 0, "Length of the break, 0s for none (default from config)")
+
+	timerCmd.AddCommand(startCmd)
+	return timerCmd
+}
+
+func runTimerStart(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("work") {
+		cfg.Timer.Work, _ = cmd.Flags().GetDuration("work")
+		if cfg.Timer.Work <= 0 {
+			return fmt.Errorf("--work must be longer than zero")
+		}
+	}
+	if cmd.Flags().Changed("break") {
+		cfg.Timer.Break, _ = cmd.Flags().GetDuration("break")
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	i, err := resolveTodo(data, args[0])
+	if err != nil {
+		return err
+	}
+
+	return tui.RunTimer(s, cfg.Timer, data.Items[i].ID)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"upnext/internal/model"
+	"upnext/internal/store"
+)
+
+func newStartCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "start <id>",
+		Short: "Start tracking time on a task",
+		Long: `Start tracking time on a task. Only one task is tracked at a time, so
+starting another task stops the running one.
+
+The start time is saved right away, so tracking carries on across
+terminals and restarts until 'upnext stop' or the task is completed.
+
+<id> is the task's number in 'upnext list' or (a unique part of) its ID.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runStart,
+	}
+}
+
+func newStopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "Stop tracking time",
+		Args:  cobra.NoArgs,
+		RunE:  runStop,
+	}
+}
+
+func runStart(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	i, err := resolveTodo(data, args[0])
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	stopped, ok, err := data.StartTracking(i, now)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Save(data); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+	if ok {
+		printStopped(data.Items[stopped], now)
+	}
+	fmt.Printf("Tracking: %s\n", data.Items[i].Text)
+	return nil
+}
+
+func runStop(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	data, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	now := time.Now()
+	i, ok := data.StopTracking(now)
+	if !ok {
+		return fmt.Errorf("no task is being tracked")
+	}
+
+	if err := s.Save(data); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+	printStopped(data.Items[i], now)
+	return nil
+}
+
+// printStopped reports the entry that was just stopped on todo
+func printStopped(todo model.Todo, now time.Time) {
+	last := todo.TimeEntries[len(todo.TimeEntries)-1]
+	fmt.Printf("Stopped: %s after %s (%s total)\n", todo.Text, model.FormatTracked(last.Duration(now)), model.FormatTracked(todo.TrackedTime(now)))
+}
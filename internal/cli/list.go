@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"upnext/internal/model"
+)
+
+// DefaultListFormat is the template used by `upnext list` when --format is not set
+const DefaultListFormat = "{{.Index}}. [{{.Priority.Icon}}] {{.Text}}"
+
+// Sort orders supported by ListOptions.Sort
+const (
+	SortPosition = "position"
+	SortCreated  = "created"
+	SortPriority = "priority"
+	SortDue      = "due"
+)
+
+// Filter selects which tasks are included in scripted output
+type Filter struct {
+	Cwd      string          // Directory used for context relevance
+	Checkout model.Checkout  // Git checkout Cwd is in, for tasks anchored to its repository
+	All      bool            // Ignore context and include every task
+	Priority *model.Priority // Only include tasks with this priority (nil = any)
+	Since    time.Time       // Only include tasks created (or completed, when archived) at or after this time
+	Upcoming bool            // Include active tasks scheduled for later or snoozed
+	Tag      string          // Only include tasks with this tag
+}
+
+// Items returns the active tasks matching the filter, in list order with
+// blocked tasks last
+func (f Filter) Items(data *model.Data) []model.Todo {
+	var items []model.Todo
+	branches := model.Branches{}
+	for _, item := range data.Items {
+		if !f.matchContext(item.ContextIn(f.Checkout)) || !f.matchPriority(item.Priority) || !f.matchTag(item.Tags) {
+			continue
+		}
+		if !f.All && !branches.Visible(item, f.Checkout) {
+			continue
+		}
+		if !f.Since.IsZero() && item.Created.Before(f.Since) {
+			continue
+		}
+		if !f.Upcoming && (item.IsScheduledLater(time.Now()) || item.IsSnoozed(time.Now())) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return data.SortBlockedLast(items)
+}
+
+// Archive returns the archived tasks matching the filter, most recently completed first
+func (f Filter) Archive(data *model.Data) []model.ArchivedTodo {
+	var items []model.ArchivedTodo
+	for i := len(data.Archive) - 1; i >= 0; i-- {
+		item := data.Archive[i]
+		if !f.matchContext(item.ContextIn(f.Checkout)) || !f.matchPriority(item.Priority) || !f.matchTag(item.Tags) {
+			continue
+		}
+		if !f.Since.IsZero() && item.Completed.Before(f.Since) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (f Filter) matchContext(ctx string) bool {
+	return f.All || f.Cwd == "" || model.IsContextRelevant(ctx, f.Cwd)
+}
+
+func (f Filter) matchPriority(p model.Priority) bool {
+	return f.Priority == nil || *f.Priority == p
+}
+
+func (f Filter) matchTag(tags []string) bool {
+	return f.Tag == "" || model.HasTag(tags, f.Tag)
+}
+
+// ListOptions configures RenderList
+type ListOptions struct {
+	Filter
+	Archived bool   // List completed tasks instead of active ones
+	Sort     string // One of SortPosition, SortCreated, SortPriority
+	Limit    int    // Maximum number of rows (0 = unlimited)
+	Format   string // text/template applied to each ListItem
+}
+
+// ListItem is a single row exposed to --format templates
+type ListItem struct {
+	Index       int // 1-based row number in the output
+	ID          string
+	Text        string
+	Description string
+	Priority    model.Priority // Prints as "High"; use .Priority.Icon for "!!!"
+	Created     time.Time
+	Completed   time.Time // Zero for active tasks
+	Due         time.Time // Zero when no due date is set
+	Scheduled   time.Time // Zero when not scheduled
+	Tags        []string
+	Repeat      string // Recurrence rule, "" for one-off tasks
+	Steps       []model.Step
+	Progress    string // Checklist progress such as "2/5", "" without steps
+	Blocked     bool   // Waiting on another active task
+	Estimate    string // Expected effort such as "30m" or "3pt", "" when unset
+	Position    int    // Place in the whole active list, 0-based, whatever the filter; -1 when completed
+	Context     string // Context relative to the filter directory ("global", ".", "sub/dir")
+	Path        string // Raw context path as stored
+	Done        bool
+}
+
+// RenderList renders tasks one per line using opts.Format
+func RenderList(data *model.Data, opts ListOptions) (string, error) {
+	format := opts.Format
+	if format == "" {
+		format = DefaultListFormat
+	}
+	tmpl, err := template.New("list").Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid format: %w", err)
+	}
+
+	rows, err := ListItems(data, opts)
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for _, row := range rows {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, row); err != nil {
+			return "", fmt.Errorf("format: %w", err)
+		}
+		lines = append(lines, b.String())
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// ListItems returns the filtered, sorted and limited rows for opts
+func ListItems(data *model.Data, opts ListOptions) ([]ListItem, error) {
+	var rows []ListItem
+	if opts.Archived {
+		for _, item := range opts.Filter.Archive(data) {
+			rows = append(rows, ListItem{
+				ID:          item.ID,
+				Text:        item.Text,
+				Description: item.Description,
+				Priority:    item.Priority,
+				Created:     item.Created,
+				Completed:   item.Completed,
+				Due:         timeOrZero(item.Due),
+				Scheduled:   timeOrZero(item.Scheduled),
+				Tags:        item.Tags,
+				Repeat:      item.Repeat,
+				Steps:       item.Steps,
+				Progress:    model.StepSummary(item.Steps),
+				Estimate:    item.Estimate,
+				Position:    -1,
+				Context:     model.GetContextDisplay(item.ContextIn(opts.Checkout), opts.Cwd),
+				Path:        item.ContextIn(opts.Checkout),
+				Done:        true,
+			})
+		}
+	} else {
+		for _, item := range opts.Filter.Items(data) {
+			rows = append(rows, ListItem{
+				ID:          item.ID,
+				Text:        item.Text,
+				Description: item.Description,
+				Priority:    item.Priority,
+				Created:     item.Created,
+				Due:         timeOrZero(item.Due),
+				Scheduled:   timeOrZero(item.Scheduled),
+				Tags:        item.Tags,
+				Repeat:      item.Repeat,
+				Steps:       item.Steps,
+				Progress:    model.StepSummary(item.Steps),
+				Blocked:     data.IsBlocked(item),
+				Estimate:    item.Estimate,
+				Position:    item.Position,
+				Context:     model.GetContextDisplay(item.ContextIn(opts.Checkout), opts.Cwd),
+				Path:        item.ContextIn(opts.Checkout),
+			})
+		}
+	}
+
+	switch opts.Sort {
+	case "", SortPosition:
+		// Already in list order
+	case SortCreated:
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].Created.After(rows[j].Created)
+		})
+	case SortPriority:
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].Priority > rows[j].Priority
+		})
+	case SortDue:
+		sort.SliceStable(rows, func(i, j int) bool {
+			a, b := rows[i].Due, rows[j].Due
+			if a.IsZero() || b.IsZero() {
+				return !a.IsZero()
+			}
+			return a.Before(b)
+		})
+	default:
+		return nil, fmt.Errorf("unknown sort %q (use created, priority, due, or position)", opts.Sort)
+	}
+
+	if opts.Limit > 0 && len(rows) > opts.Limit {
+		rows = rows[:opts.Limit]
+	}
+	for i := range rows {
+		rows[i].Index = i + 1
+	}
+	return rows, nil
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// ParseSince parses a --since value. It accepts a relative age such as
+// "90m", "36h", "7d" or "2w", a recent day such as "yesterday" or "monday",
+// or an absolute date in YYYY-MM-DD form.
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+
+	if t, ok := model.ParsePastDay(s, now); ok {
+		return t, nil
+	}
+
+	if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n >= 0 {
+		switch s[len(s)-1] {
+		case 'd':
+			return now.AddDate(0, 0, -n), nil
+		case 'w':
+			return now.AddDate(0, 0, -7*n), nil
+		}
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --since value %q (use e.g. 7d, 2w, 36h, monday or 2006-01-02)", s)
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"upnext/internal/model"
+)
+
+func TestParseSince(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"", time.Time{}},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"0d", now},
+		{"2w", now.AddDate(0, 0, -14)},
+		{"36h", now.Add(-36 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"yesterday", time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)},
+		{"monday", time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)},
+		{"wed", time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)},
+		{" 2026-09-01 ", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseSince(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseSince(%q) error: %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseSince(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"d", "-3d", "soon", "2026-13-01"} {
+		if _, err := ParseSince(input, now); err == nil {
+			t.Errorf("ParseSince(%q) expected error", input)
+		}
+	}
+}
+
+func TestFilterItems(t *testing.T) {
+	now := time.Now()
+	later := now.Add(48 * time.Hour)
+	high := model.PriorityHigh
+
+	data := model.NewData()
+	data.Items = []model.Todo{
+		{ID: "a", Text: "here", Context: "/work/api", Priority: model.PriorityHigh, Created: now, Tags: []string{"bug"}},
+		{ID: "b", Text: "below", Context: "/work/api/cmd", Created: now.AddDate(0, 0, -10)},
+		{ID: "c", Text: "elsewhere", Context: "/work/web", Created: now},
+		{ID: "d", Text: "global", Created: now, Tags: []string{"bug"}},
+		{ID: "e", Text: "scheduled", Context: "/work/api", Created: now, Scheduled: &later},
+		{ID: "f", Text: "blocked", Context: "/work/api", Created: now, BlockedBy: []string{"a"}},
+		{ID: "g", Text: "last", Context: "/work/api", Created: now},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"context", Filter{Cwd: "/work/api"}, []string{"a", "b", "d", "g", "f"}},
+		{"other context", Filter{Cwd: "/work/web"}, []string{"c", "d"}},
+		{"all", Filter{Cwd: "/work/api", All: true}, []string{"a", "b", "c", "d", "g", "f"}},
+		{"priority", Filter{Cwd: "/work/api", Priority: &high}, []string{"a"}},
+		{"since", Filter{All: true, Since: now.AddDate(0, 0, -1)}, []string{"a", "c", "d", "g", "f"}},
+		{"tag", Filter{All: true, Tag: "#BUG"}, []string{"a", "d"}},
+		{"upcoming", Filter{Cwd: "/work/api", Upcoming: true}, []string{"a", "b", "d", "e", "g", "f"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, item := range tt.filter.Items(data) {
+				got = append(got, item.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Items() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterArchive(t *testing.T) {
+	now := time.Now()
+	data := model.NewData()
+	data.Archive = []model.ArchivedTodo{
+		{ID: "old", Context: "/work/api", Completed: now.AddDate(0, 0, -30)},
+		{ID: "other", Context: "/work/web", Completed: now.AddDate(0, 0, -2)},
+		{ID: "new", Context: "/work/api", Completed: now.AddDate(0, 0, -1)},
+	}
+
+	var got []string
+	for _, item := range (Filter{Cwd: "/work/api", Since: now.AddDate(0, 0, -7)}).Archive(data) {
+		got = append(got, item.ID)
+	}
+	if want := []string{"new"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Archive() = %v, want %v", got, want)
+	}
+
+	got = nil
+	for _, item := range (Filter{All: true}).Archive(data) {
+		got = append(got, item.ID)
+	}
+	if want := []string{"new", "other", "old"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Archive() = %v, want most recent first %v", got, want)
+	}
+}
+
+func TestRenderList(t *testing.T) {
+	data := model.NewData()
+	data.Items = []model.Todo{
+		{ID: "a", Text: "low", Priority: model.PriorityLow},
+		{ID: "b", Text: "high", Priority: model.PriorityHigh, Position: 1},
+		{ID: "c", Text: "medium", Priority: model.PriorityMedium, Position: 2},
+	}
+
+	got, err := RenderList(data, ListOptions{Filter: Filter{All: true}, Sort: SortPriority, Limit: 2, Format: "{{.Index}} {{.Text}}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "1 high\n2 medium"; got != want {
+		t.Errorf("RenderList() = %q, want %q", got, want)
+	}
+
+	// Positions are the tasks' own, not where the filter left them
+	medium := model.PriorityMedium
+	got, err = RenderList(data, ListOptions{Filter: Filter{All: true, Priority: &medium}, Format: "{{.Index}} {{.Position}} {{.Text}}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "1 2 medium"; got != want {
+		t.Errorf("RenderList() with a filter = %q, want %q", got, want)
+	}
+
+	if _, err := RenderList(data, ListOptions{Sort: "size"}); err == nil {
+		t.Error("RenderList with an unknown sort succeeded")
+	}
+	if _, err := RenderList(data, ListOptions{Format: "{{.Missing"}); err == nil {
+		t.Error("RenderList with a broken template succeeded")
+	}
+}
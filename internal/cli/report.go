@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"upnext/internal/model"
+)
+
+// TimeTotal is the time tracked under one context or tag
+type TimeTotal struct {
+	Label   string
+	Tracked time.Duration
+}
+
+// Report sums up time tracked on the filtered tasks since a point in time
+type Report struct {
+	Since    time.Time // Zero for all time
+	Total    time.Duration
+	Contexts []TimeTotal // Most time first
+	Tags     []TimeTotal // Most time first; a task counts toward each of its tags
+}
+
+// BuildReport totals the time tracked on active and completed tasks
+// matching filter since the given time, counting running entries up to now
+func BuildReport(data *model.Data, filter Filter, since, now time.Time) Report {
+	contexts := map[string]time.Duration{}
+	tags := map[string]time.Duration{}
+	report := Report{Since: since}
+
+	add := func(ctx string, taskTags []string, entries []model.TimeEntry, end time.Time) {
+		tracked := model.TrackedSince(entries, since, end)
+		if tracked == 0 {
+			return
+		}
+		report.Total += tracked
+		contexts[reportContext(ctx, filter)] += tracked
+		if len(taskTags) == 0 {
+			tags[""] += tracked
+		}
+		for _, tag := range taskTags {
+			tags[tag] += tracked
+		}
+	}
+
+	// Snoozed and scheduled tasks may have time on them too
+	filter.Upcoming = true
+	for _, item := range filter.Items(data) {
+		add(item.ContextIn(filter.Checkout), item.Tags, item.TimeEntries, now)
+	}
+	for _, item := range filter.Archive(data) {
+		add(item.ContextIn(filter.Checkout), item.Tags, item.TimeEntries, item.Completed)
+	}
+
+	report.Contexts = sortTotals(contexts)
+	report.Tags = sortTotals(tags)
+	return report
+}
+
+// reportContext labels a task context relative to the report's directory
+func reportContext(ctx string, filter Filter) string {
+	if ctx != "" && (filter.All || filter.Cwd == "") {
+		return ctx
+	}
+	return model.GetContextDisplay(ctx, filter.Cwd)
+}
+
+// sortTotals orders totals by time spent, then label. The empty label
+// (untagged) always goes last.
+func sortTotals(totals map[string]time.Duration) []TimeTotal {
+	result := make([]TimeTotal, 0, len(totals))
+	for label, tracked := range totals {
+		result = append(result, TimeTotal{Label: label, Tracked: tracked})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if (a.Label == "") != (b.Label == "") {
+			return b.Label == ""
+		}
+		if a.Tracked != b.Tracked {
+			return a.Tracked > b.Tracked
+		}
+		return a.Label < b.Label
+	})
+	return result
+}
+
+// RenderReport formats a Report for `upnext report`
+func RenderReport(report Report) string {
+	title := "Time tracked:"
+	if !report.Since.IsZero() {
+		title = "Time tracked since " + report.Since.Format("Mon Jan 2 15:04") + ":"
+	}
+
+	var lines []string
+	lines = append(lines, title)
+	lines = append(lines, strings.Repeat("-", 50))
+	if report.Total == 0 {
+		lines = append(lines, "Nothing tracked. Start with: upnext start <id>")
+		return strings.Join(lines, "\n")
+	}
+	lines = append(lines, fmt.Sprintf("%-30s %10s", "Total", model.FormatTracked(report.Total)))
+
+	lines = append(lines, "")
+	lines = append(lines, "Contexts:")
+	lines = append(lines, strings.Repeat("-", 50))
+	for _, t := range report.Contexts {
+		lines = append(lines, fmt.Sprintf("%-30s %10s", t.Label, model.FormatTracked(t.Tracked)))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "Tags:")
+	lines = append(lines, strings.Repeat("-", 50))
+	for _, t := range report.Tags {
+		label := "#" + t.Label
+		if t.Label == "" {
+			label = "(untagged)"
+		}
+		lines = append(lines, fmt.Sprintf("%-30s %10s", label, model.FormatTracked(t.Tracked)))
+	}
+
+	return strings.Join(lines, "\n")
+}
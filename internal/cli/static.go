@@ -4,13 +4,89 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"upnext/internal/model"
 )
 
-// RenderPlain outputs the todo list in plain text format
-func RenderPlain(data *model.Data) string {
-	if len(data.Items) == 0 {
+// JSONSchemaVersion identifies the shape of RenderJSON output. It is bumped
+// whenever a field is removed, renamed or changes meaning; new fields may be
+// added without a bump, so consumers should ignore fields they don't know.
+const JSONSchemaVersion = 1
+
+// JSONOutput is the document printed by `upnext --json`
+type JSONOutput struct {
+	SchemaVersion int                `json:"schema_version"`
+	Context       string             `json:"context"` // Directory used for filtering, "" when --all
+	Items         []JSONTodo         `json:"items"`   // Active tasks in list order, never null
+	Archive       []JSONArchivedTodo `json:"archive"` // Completed tasks, most recent first; empty unless --archived
+	Stats         JSONStats          `json:"stats"`
+}
+
+// JSONTodo is an active task in JSONOutput
+type JSONTodo struct {
+	ID           string     `json:"id"`
+	Text         string     `json:"text"`
+	Description  string     `json:"description"`
+	Priority     string     `json:"priority"`      // "High", "Medium" or "Low"
+	Created      string     `json:"created"`       // RFC 3339
+	Position     int        `json:"position"`      // 0-based index within items
+	Context      string     `json:"context"`       // Absolute directory, "" for global tasks
+	Due          *string    `json:"due"`           // YYYY-MM-DD or null
+	Scheduled    *string    `json:"scheduled"`     // RFC 3339 or null; hidden from items until then
+	Tags         []string   `json:"tags"`          // Lowercase tags without '#', never null
+	Repeat       string     `json:"repeat"`        // Recurrence rule such as "weekly:mon", "" when one-off
+	SnoozedUntil *string    `json:"snoozed_until"` // RFC 3339 or null; hidden from items until then
+	Steps        []JSONStep `json:"steps"`         // Checklist in order, never null
+	BlockedBy    []string   `json:"blocked_by"`    // IDs of tasks this one waits on, never null
+	Blocked      bool       `json:"blocked"`       // Whether any of blocked_by is still active
+	Pomodoros    int        `json:"pomodoros"`     // Completed work sessions
+	Tracked      int64      `json:"tracked"`       // Seconds of time tracked, including a running entry
+	Tracking     bool       `json:"tracking"`      // Whether time is being tracked on the task now
+	Estimate     string     `json:"estimate"`      // Such as "30m", "1h30m" or "3pt", "" when unset
+}
+
+// JSONArchivedTodo is a completed task in JSONOutput
+type JSONArchivedTodo struct {
+	ID          string     `json:"id"`
+	Text        string     `json:"text"`
+	Description string     `json:"description"`
+	Priority    string     `json:"priority"`
+	Created     string     `json:"created"`
+	Completed   string     `json:"completed"`
+	Context     string     `json:"context"`
+	Due         *string    `json:"due"`
+	Scheduled   *string    `json:"scheduled"`
+	Tags        []string   `json:"tags"`
+	Repeat      string     `json:"repeat"`
+	Steps       []JSONStep `json:"steps"`
+	Pomodoros   int        `json:"pomodoros"`
+	Tracked     int64      `json:"tracked"`
+	Estimate    string     `json:"estimate"`
+}
+
+// JSONStep is one checklist item of a task
+type JSONStep struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// JSONStats mirrors model.Stats in JSONOutput
+type JSONStats struct {
+	TotalCompleted int `json:"total_completed"`
+	StreakDays     int `json:"streak_days"`
+}
+
+// RenderPlain outputs the todo list in plain text format. Completed tasks
+// are appended in their own section when archived is true.
+func RenderPlain(data *model.Data, filter Filter, archived bool) string {
+	items := filter.Items(data)
+	var done []model.ArchivedTodo
+	if archived {
+		done = filter.Archive(data)
+	}
+
+	if len(items) == 0 && len(done) == 0 {
 		return "No tasks. Add one with: upnext add \"your task\""
 	}
 
@@ -18,20 +94,94 @@ func RenderPlain(data *model.Data) string {
 	lines = append(lines, "Tasks:")
 	lines = append(lines, strings.Repeat("-", 50))
 
-	for i, item := range data.Items {
+	for i, item := range items {
 		pri := prioritySymbol(item.Priority)
-		lines = append(lines, fmt.Sprintf("%d. [%s] %s", i+1, pri, item.Text))
+		blocked := ""
+		if data.IsBlocked(item) {
+			blocked = " [blocked]"
+		}
+		lines = append(lines, fmt.Sprintf("%d. [%s] %s%s%s%s%s%s", i+1, pri, item.Text, blocked, plainSteps(item.Steps), plainTags(item.Tags), plainDue(item.Due), plainContext(item.ContextIn(filter.Checkout), filter.Cwd)))
 		if item.Description != "" {
 			lines = append(lines, fmt.Sprintf("      %s", item.Description))
 		}
 	}
 
+	if archived {
+		lines = append(lines, "")
+		lines = append(lines, "Completed:")
+		lines = append(lines, strings.Repeat("-", 50))
+		for _, item := range done {
+			lines = append(lines, fmt.Sprintf("x [%s] %s%s%s", prioritySymbol(item.Priority), item.Text, plainTags(item.Tags), plainContext(item.ContextIn(filter.Checkout), filter.Cwd)))
+		}
+	}
+
 	lines = append(lines, strings.Repeat("-", 50))
-	lines = append(lines, fmt.Sprintf("%d items | %d completed total", len(data.Items), data.Stats.TotalCompleted))
+	lines = append(lines, fmt.Sprintf("%d items | %d completed total", len(items), data.Stats.TotalCompleted))
 
 	return strings.Join(lines, "\n")
 }
 
+// plainContext returns a " (dir)" suffix unless the task belongs to cwd itself
+func plainContext(ctx, cwd string) string {
+	if cwd == "" && ctx != "" {
+		return " (" + ctx + ")"
+	}
+	display := model.GetContextDisplay(ctx, cwd)
+	if display == "." || display == "" {
+		return ""
+	}
+	return " (" + display + ")"
+}
+
+// plainDue returns a " due Jan 2" suffix for tasks with a due date
+func plainDue(due *time.Time) string {
+	if due == nil {
+		return ""
+	}
+	return " due " + due.Format("Jan 2")
+}
+
+// plainSteps returns a " (2/5)" suffix for tasks with a checklist
+func plainSteps(steps []model.Step) string {
+	if len(steps) == 0 {
+		return ""
+	}
+	return " (" + model.StepSummary(steps) + ")"
+}
+
+// plainTags returns a " #a #b" suffix for tagged tasks
+func plainTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return " #" + strings.Join(tags, " #")
+}
+
+// orEmpty returns s, or an empty slice when s is nil, so it encodes as []
+func orEmpty(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func jsonSteps(steps []model.Step) []JSONStep {
+	out := make([]JSONStep, len(steps))
+	for i, s := range steps {
+		out[i] = JSONStep{Text: s.Text, Done: s.Done}
+	}
+	return out
+}
+
+// formatOptional formats t with layout, or returns nil when t is unset
+func formatOptional(t *time.Time, layout string) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(layout)
+	return &s
+}
+
 func prioritySymbol(p model.Priority) string {
 	switch p {
 	case model.PriorityHigh:
@@ -43,49 +193,69 @@ func prioritySymbol(p model.Priority) string {
 	}
 }
 
-// RenderJSON outputs the todo list in JSON format
-func RenderJSON(data *model.Data) (string, error) {
-	output := struct {
-		Items []struct {
-			ID          string `json:"id"`
-			Text        string `json:"text"`
-			Description string `json:"description,omitempty"`
-			Priority    string `json:"priority"`
-			Created     string `json:"created"`
-			Position    int    `json:"position"`
-		} `json:"items"`
-		Stats struct {
-			TotalCompleted int `json:"total_completed"`
-			StreakDays     int `json:"streak_days"`
-		} `json:"stats"`
-	}{
-		Stats: struct {
-			TotalCompleted int `json:"total_completed"`
-			StreakDays     int `json:"streak_days"`
-		}{
+// RenderJSON outputs the todo list in JSON format. The document follows
+// JSONOutput; archive is only populated when archived is true.
+func RenderJSON(data *model.Data, filter Filter, archived bool) (string, error) {
+	output := JSONOutput{
+		SchemaVersion: JSONSchemaVersion,
+		Items:         []JSONTodo{},
+		Archive:       []JSONArchivedTodo{},
+		Stats: JSONStats{
 			TotalCompleted: data.Stats.TotalCompleted,
 			StreakDays:     data.Stats.StreakDays,
 		},
 	}
+	if !filter.All {
+		output.Context = filter.Cwd
+	}
 
-	for _, item := range data.Items {
-		output.Items = append(output.Items, struct {
-			ID          string `json:"id"`
-			Text        string `json:"text"`
-			Description string `json:"description,omitempty"`
-			Priority    string `json:"priority"`
-			Created     string `json:"created"`
-			Position    int    `json:"position"`
-		}{
-			ID:          item.ID,
-			Text:        item.Text,
-			Description: item.Description,
-			Priority:    item.Priority.String(),
-			Created:     item.Created.Format("2006-01-02T15:04:05Z07:00"),
-			Position:    item.Position,
+	now := time.Now()
+	for i, item := range filter.Items(data) {
+		output.Items = append(output.Items, JSONTodo{
+			ID:           item.ID,
+			Text:         item.Text,
+			Description:  item.Description,
+			Priority:     item.Priority.String(),
+			Created:      item.Created.Format(time.RFC3339),
+			Position:     i,
+			Context:      item.ContextIn(filter.Checkout),
+			Due:          formatOptional(item.Due, "2006-01-02"),
+			Scheduled:    formatOptional(item.Scheduled, time.RFC3339),
+			Tags:         orEmpty(item.Tags),
+			Repeat:       item.Repeat,
+			SnoozedUntil: formatOptional(item.SnoozedUntil, time.RFC3339),
+			Steps:        jsonSteps(item.Steps),
+			BlockedBy:    orEmpty(item.BlockedBy),
+			Blocked:      data.IsBlocked(item),
+			Pomodoros:    len(item.Pomodoros),
+			Tracked:      int64(item.TrackedTime(now).Seconds()),
+			Tracking:     item.IsTracking(),
+			Estimate:     item.Estimate,
 		})
 	}
 
+	if archived {
+		for _, item := range filter.Archive(data) {
+			output.Archive = append(output.Archive, JSONArchivedTodo{
+				ID:          item.ID,
+				Text:        item.Text,
+				Description: item.Description,
+				Priority:    item.Priority.String(),
+				Created:     item.Created.Format(time.RFC3339),
+				Completed:   item.Completed.Format(time.RFC3339),
+				Context:     item.ContextIn(filter.Checkout),
+				Due:         formatOptional(item.Due, "2006-01-02"),
+				Scheduled:   formatOptional(item.Scheduled, time.RFC3339),
+				Tags:        orEmpty(item.Tags),
+				Repeat:      item.Repeat,
+				Steps:       jsonSteps(item.Steps),
+				Pomodoros:   len(item.Pomodoros),
+				Tracked:     int64(item.TrackedTime().Seconds()),
+				Estimate:    item.Estimate,
+			})
+		}
+	}
+
 	jsonData, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		return "", err
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"upnext/internal/model"
+)
+
+// TagCount holds how many active and completed tasks carry a tag
+type TagCount struct {
+	Tag    string
+	Active int
+	Done   int
+}
+
+// CountTags tallies tags across the filtered active and archived tasks,
+// most used first. Untagged tasks are counted under the empty tag.
+func CountTags(data *model.Data, filter Filter) []TagCount {
+	counts := map[string]*TagCount{}
+	get := func(tag string) *TagCount {
+		if counts[tag] == nil {
+			counts[tag] = &TagCount{Tag: tag}
+		}
+		return counts[tag]
+	}
+
+	for _, item := range filter.Items(data) {
+		if len(item.Tags) == 0 {
+			get("").Active++
+		}
+		for _, tag := range item.Tags {
+			get(tag).Active++
+		}
+	}
+	for _, item := range filter.Archive(data) {
+		if len(item.Tags) == 0 {
+			get("").Done++
+		}
+		for _, tag := range item.Tags {
+			get(tag).Done++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		// Untagged always goes last
+		if (a.Tag == "") != (b.Tag == "") {
+			return b.Tag == ""
+		}
+		if a.Active+a.Done != b.Active+b.Done {
+			return a.Active+a.Done > b.Active+b.Done
+		}
+		return a.Tag < b.Tag
+	})
+	return result
+}
+
+// CountPomodoros returns how many work sessions were completed on the
+// filtered tasks in total, and how many of those ended today
+func CountPomodoros(data *model.Data, filter Filter, now time.Time) (total, today int) {
+	day := model.StartOfDay(now)
+	count := func(sessions []time.Time) {
+		for _, end := range sessions {
+			total++
+			if !end.Before(day) {
+				today++
+			}
+		}
+	}
+	for _, item := range filter.Items(data) {
+		count(item.Pomodoros)
+	}
+	for _, item := range filter.Archive(data) {
+		count(item.Pomodoros)
+	}
+	return total, today
+}
+
+// Accuracy compares estimates with how long completed tasks actually took.
+// The actual time is the tracked time, or the time from creation to
+// completion for tasks nobody tracked.
+type Accuracy struct {
+	Tasks        int           // Completed tasks with a time estimate
+	Tracked      int           // How many of those had tracked time
+	Estimated    time.Duration // Sum of their estimates
+	Actual       time.Duration // Sum of their actual times
+	PointTasks   int           // Completed tasks with a point estimate
+	Points       int           // Sum of their points
+	PointsActual time.Duration // Sum of their actual times
+}
+
+// Ratio returns actual over estimated time, 0 without time estimates
+func (a Accuracy) Ratio() float64 {
+	if a.Estimated == 0 {
+		return 0
+	}
+	return float64(a.Actual) / float64(a.Estimated)
+}
+
+// MeasureAccuracy compares estimates with actual times on the filtered
+// completed tasks
+func MeasureAccuracy(data *model.Data, filter Filter) Accuracy {
+	var a Accuracy
+	for _, item := range filter.Archive(data) {
+		e := item.GetEstimate()
+		if e == (model.Estimate{}) {
+			continue
+		}
+		actual := item.TrackedTime()
+		tracked := actual > 0
+		if !tracked {
+			actual = item.Completed.Sub(item.Created)
+		}
+		if e.Points > 0 {
+			a.PointTasks++
+			a.Points += e.Points
+			a.PointsActual += actual
+			continue
+		}
+		a.Tasks++
+		if tracked {
+			a.Tracked++
+		}
+		a.Estimated += e.Duration()
+		a.Actual += actual
+	}
+	return a
+}
+
+// RenderStats summarizes completion metrics for `upnext stats`
+func RenderStats(data *model.Data, filter Filter, now time.Time) string {
+	items := filter.Items(data)
+	archive := filter.Archive(data)
+
+	var lines []string
+	lines = append(lines, "Stats:")
+	lines = append(lines, strings.Repeat("-", 50))
+	lines = append(lines, fmt.Sprintf("%-20s %d", "Active", len(items)))
+	lines = append(lines, fmt.Sprintf("%-20s %d (%d total)", "Completed", len(archive), data.Stats.TotalCompleted))
+	lines = append(lines, fmt.Sprintf("%-20s %d days", "Streak", data.Stats.StreakDays))
+	pomodoros, today := CountPomodoros(data, filter, now)
+	lines = append(lines, fmt.Sprintf("%-20s %d (%d today)", "Work sessions", pomodoros, today))
+
+	if a := MeasureAccuracy(data, filter); a.Tasks > 0 || a.PointTasks > 0 {
+		lines = append(lines, "")
+		lines = append(lines, "Estimates:")
+		lines = append(lines, strings.Repeat("-", 50))
+		if a.Tasks > 0 {
+			lines = append(lines, fmt.Sprintf("%-20s %d (%d tracked, %d by elapsed time)", "Estimated tasks", a.Tasks, a.Tracked, a.Tasks-a.Tracked))
+			lines = append(lines, fmt.Sprintf("%-20s %s", "Estimated", model.FormatTracked(a.Estimated)))
+			lines = append(lines, fmt.Sprintf("%-20s %s", "Actual", model.FormatTracked(a.Actual)))
+			lines = append(lines, fmt.Sprintf("%-20s took %.1fx the estimate", "Accuracy", a.Ratio()))
+		}
+		if a.PointTasks > 0 {
+			perPoint := a.PointsActual / time.Duration(a.Points)
+			lines = append(lines, fmt.Sprintf("%-20s ~%s per point over %dpt", "Points", model.FormatTracked(perPoint), a.Points))
+		}
+	}
+
+	tags := CountTags(data, filter)
+	if len(tags) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("%-20s %6s %6s", "Tags:", "active", "done"))
+		lines = append(lines, strings.Repeat("-", 50))
+		for _, c := range tags {
+			label := "#" + c.Tag
+			if c.Tag == "" {
+				label = "(untagged)"
+			}
+			lines = append(lines, fmt.Sprintf("%-20s %6d %6d", label, c.Active, c.Done))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
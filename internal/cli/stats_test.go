@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"upnext/internal/model"
+)
+
+func TestCountTags(t *testing.T) {
+	now := time.Now()
+	data := model.NewData()
+	data.Items = []model.Todo{
+		{ID: "a", Context: "/work", Tags: []string{"bug", "api"}},
+		{ID: "b", Context: "/work", Tags: []string{"bug"}},
+		{ID: "c", Context: "/work"},
+		{ID: "d", Context: "/elsewhere", Tags: []string{"bug", "web"}},
+	}
+	data.Archive = []model.ArchivedTodo{
+		{ID: "e", Context: "/work", Tags: []string{"api"}, Completed: now},
+		{ID: "f", Context: "/work", Tags: []string{"docs"}, Completed: now},
+		{ID: "g", Context: "/work", Completed: now},
+	}
+
+	got := CountTags(data, Filter{Cwd: "/work"})
+	want := []TagCount{
+		{Tag: "api", Active: 1, Done: 1},
+		{Tag: "bug", Active: 2},
+		{Tag: "docs", Done: 1},
+		{Tag: "", Active: 1, Done: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CountTags() = %+v\nwant %+v", got, want)
+	}
+
+	got = CountTags(data, Filter{All: true, Tag: "bug"})
+	want = []TagCount{
+		{Tag: "bug", Active: 3},
+		{Tag: "api", Active: 1},
+		{Tag: "web", Active: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CountTags() with a tag filter = %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds the user's settings. Every setting is optional; anything
+// left out of the file keeps its Default value.
+type Config struct {
+	Timer   Timer   `toml:"timer"`
+	TUI     TUI     `toml:"tui"`
+	Archive Archive `toml:"archive"`
+	Store   Store   `toml:"store"`
+	Context Context `toml:"context"`
+}
+
+// Timer configures the work timer
+type Timer struct {
+	Work  time.Duration `toml:"work"`  // Length of a work session, e.g. "25m"
+	Break time.Duration `toml:"break"` // Length of the break that follows, e.g. "5m"
+}
+
+// TUI configures the interactive list
+type TUI struct {
+	Confirm bool `toml:"confirm"` // Ask before deleting tasks or completing several at once
+}
+
+// Archive configures how long completed tasks stay in the main data file
+type Archive struct {
+	// Completed tasks older than this many days move to cold storage on
+	// save, which keeps the main file small; 0 keeps them all in it
+	RetentionDays int `toml:"retention_days"`
+}
+
+// Store formats
+const (
+	StoreJSON   = "json"   // One JSON document, rewritten on every save
+	StoreEvents = "events" // Append-only log of changes, replayed on load
+)
+
+// Store configures how tasks are saved
+type Store struct {
+	Format string `toml:"format"` // StoreJSON or StoreEvents
+}
+
+// Ways of anchoring tasks to where they were added
+const (
+	AnchorPath = "path" // The directory's absolute path
+	AnchorRepo = "repo" // The git repository and the path within it, so moving or recloning it keeps its tasks
+)
+
+// Context configures how tasks are tied to directories
+type Context struct {
+	Anchor string `toml:"anchor"` // AnchorPath or AnchorRepo
+}
+
+// Retention returns how long completed tasks are kept, zero for forever
+func (a Archive) Retention() time.Duration {
+	return time.Duration(a.RetentionDays) * 24 * time.Hour
+}
+
+// Default returns the settings used when there is no config file
+func Default() Config {
+	return Config{
+		Timer: Timer{
+			Work:  25 * time.Minute,
+			Break: 5 * time.Minute,
+		},
+		TUI: TUI{
+			Confirm: true,
+		},
+		Archive: Archive{
+			RetentionDays: 30,
+		},
+		Store: Store{
+			Format: StoreJSON,
+		},
+		Context: Context{
+			Anchor: AnchorPath,
+		},
+	}
+}
+
+// Load reads the config file, falling back to Default for anything it
+// doesn't set. A missing file is not an error.
+func Load() (Config, error) {
+	cfg := Default()
+
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return Default(), fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Bare numbers decode as nanoseconds, which is never what was meant
+	if cfg.Timer.Work < time.Second || (cfg.Timer.Break != 0 && cfg.Timer.Break < time.Second) {
+		return Default(), fmt.Errorf("%s: timer lengths need a unit, e.g. work = \"25m\"", path)
+	}
+	if cfg.Archive.RetentionDays < 0 {
+		return Default(), fmt.Errorf("%s: archive retention_days can't be negative", path)
+	}
+	if cfg.Store.Format != StoreJSON && cfg.Store.Format != StoreEvents {
+		return Default(), fmt.Errorf("%s: unknown store format %q (use %q or %q)", path, cfg.Store.Format, StoreJSON, StoreEvents)
+	}
+	if cfg.Context.Anchor != AnchorPath && cfg.Context.Anchor != AnchorRepo {
+		return Default(), fmt.Errorf("%s: unknown context anchor %q (use %q or %q)", path, cfg.Context.Anchor, AnchorPath, AnchorRepo)
+	}
+	return cfg, nil
+}
+
+// Path returns the location of the config file
+func Path() (string, error) {
+	var baseDir string
+
+	switch runtime.GOOS {
+	case "windows":
+		baseDir = os.Getenv("APPDATA")
+		if baseDir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			baseDir = filepath.Join(home, "AppData", "Roaming")
+		}
+	default:
+		// Use XDG_CONFIG_HOME or ~/.config, on macOS too
+		baseDir = os.Getenv("XDG_CONFIG_HOME")
+		if baseDir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			baseDir = filepath.Join(home, ".config")
+		}
+	}
+
+	return filepath.Join(baseDir, "upnext", "config.toml"), nil
+}
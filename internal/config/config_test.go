@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if content == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "upnext"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "upnext", "config.toml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadTimer(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		work, rest time.Duration
+	}{
+		{"no file", "", 25 * time.Minute, 5 * time.Minute},
+		{"work only", "[timer]\nwork = \"50m\"\n", 50 * time.Minute, 5 * time.Minute},
+		{"no break", "[timer]\nwork = \"1h\"\nbreak = \"0s\"\n", time.Hour, 0},
+		{"other sections", "[theme]\naccent = \"#b4befe\"\n", 25 * time.Minute, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeConfig(t, tt.content)
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if cfg.Timer.Work != tt.work || cfg.Timer.Break != tt.rest {
+				t.Errorf("timer = %s/%s, want %s/%s", cfg.Timer.Work, cfg.Timer.Break, tt.work, tt.rest)
+			}
+		})
+	}
+}
+
+func TestLoadConfirm(t *testing.T) {
+	writeConfig(t, "")
+	if cfg, err := Load(); err != nil || !cfg.TUI.Confirm {
+		t.Errorf("Load() without a file = %v, %v; want confirmations on", cfg.TUI.Confirm, err)
+	}
+
+	writeConfig(t, "[tui]\nconfirm = false\n")
+	if cfg, err := Load(); err != nil || cfg.TUI.Confirm {
+		t.Errorf("Load() with confirm = false = %v, %v; want confirmations off", cfg.TUI.Confirm, err)
+	}
+}
+
+func TestLoadRetention(t *testing.T) {
+	writeConfig(t, "")
+	if cfg, err := Load(); err != nil || cfg.Archive.Retention() != 30*24*time.Hour {
+		t.Errorf("Load() without a file = %s, %v; want 30 days", cfg.Archive.Retention(), err)
+	}
+
+	writeConfig(t, "[archive]\nretention_days = 0\n")
+	if cfg, err := Load(); err != nil || cfg.Archive.Retention() != 0 {
+		t.Errorf("Load() with retention_days = 0 = %s, %v; want no retention", cfg.Archive.Retention(), err)
+	}
+
+	writeConfig(t, "[archive]\nretention_days = 90\n")
+	if cfg, err := Load(); err != nil || cfg.Archive.Retention() != 90*24*time.Hour {
+		t.Errorf("Load() with retention_days = 90 = %s, %v", cfg.Archive.Retention(), err)
+	}
+}
+
+func TestLoadStore(t *testing.T) {
+	writeConfig(t, "")
+	if cfg, err := Load(); err != nil || cfg.Store.Format != StoreJSON {
+		t.Errorf("Load() without a file = %q, %v; want %q", cfg.Store.Format, err, StoreJSON)
+	}
+
+	writeConfig(t, "[store]\nformat = \"events\"\n")
+	if cfg, err := Load(); err != nil || cfg.Store.Format != StoreEvents {
+		t.Errorf("Load() with format = events = %q, %v", cfg.Store.Format, err)
+	}
+}
+
+func TestLoadContext(t *testing.T) {
+	writeConfig(t, "")
+	if cfg, err := Load(); err != nil || cfg.Context.Anchor != AnchorPath {
+		t.Errorf("Load() without a file = %q, %v; want %q", cfg.Context.Anchor, err, AnchorPath)
+	}
+
+	writeConfig(t, "[context]\nanchor = \"repo\"\n")
+	if cfg, err := Load(); err != nil || cfg.Context.Anchor != AnchorRepo {
+		t.Errorf("Load() with anchor = repo = %q, %v", cfg.Context.Anchor, err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	for _, content := range []string{"[timer]\nwork = 25\n", "[timer]\nbreak = 5\n", "[timer]\nwork = \"soon\"\n", "[timer\n", "[archive]\nretention_days = -1\n", "[store]\nformat = \"sqlite\"\n", "[context]\nanchor = \"inode\"\n"} {
+		writeConfig(t, content)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() with %q expected error", content)
+		}
+	}
+}
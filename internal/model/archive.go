@@ -0,0 +1,33 @@
+package model
+
+// TakeArchived removes the archived tasks match picks out of the archive
+// and returns them, in archive order
+func (d *Data) TakeArchived(match func(ArchivedTodo) bool) []ArchivedTodo {
+	var taken []ArchivedTodo
+	kept := d.Archive[:0]
+	for _, item := range d.Archive {
+		if match(item) {
+			taken = append(taken, item)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+	d.Archive = kept
+	return taken
+}
+
+// MergeArchive puts completed tasks from cold storage in front of the
+// archive, leaving out any that are still in the archive as well
+func MergeArchive(cold, archive []ArchivedTodo) []ArchivedTodo {
+	hot := make(map[string]bool, len(archive))
+	for _, item := range archive {
+		hot[item.ID] = true
+	}
+	merged := make([]ArchivedTodo, 0, len(cold)+len(archive))
+	for _, item := range cold {
+		if !hot[item.ID] {
+			merged = append(merged, item)
+		}
+	}
+	return append(merged, archive...)
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTakeArchived(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	d := NewData()
+	for i, days := range []int{400, 100, 30, 200, 1} {
+		d.Archive = append(d.Archive, ArchivedTodo{ID: string(rune('a' + i)), Completed: now.AddDate(0, 0, -days)})
+	}
+
+	cutoff := now.AddDate(0, 0, -90)
+	taken := d.TakeArchived(func(a ArchivedTodo) bool { return a.Completed.Before(cutoff) })
+
+	var takenIDs, keptIDs string
+	for _, a := range taken {
+		takenIDs += a.ID
+	}
+	for _, a := range d.Archive {
+		keptIDs += a.ID
+	}
+	if takenIDs != "abd" || keptIDs != "ce" {
+		t.Errorf("TakeArchived took %q and kept %q, want abd and ce", takenIDs, keptIDs)
+	}
+
+	if taken := d.TakeArchived(func(ArchivedTodo) bool { return false }); len(taken) != 0 || len(d.Archive) != 2 {
+		t.Errorf("TakeArchived with no matches took %d, kept %d", len(taken), len(d.Archive))
+	}
+}
+
+func TestMergeArchive(t *testing.T) {
+	cold := []ArchivedTodo{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	archive := []ArchivedTodo{{ID: "c"}, {ID: "d"}}
+
+	var ids string
+	for _, a := range MergeArchive(cold, archive) {
+		ids += a.ID
+	}
+	if ids != "abcd" {
+		t.Errorf("MergeArchive = %q, want abcd", ids)
+	}
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CurrentBranch returns the git branch checked out in the repository dir
+// is in, read from .git/HEAD so git itself isn't needed. It returns ""
+// outside a repository or when no branch is checked out.
+func CurrentBranch(dir string) string {
+	gitDir, ok := findGitDir(dir)
+	if !ok {
+		return ""
+	}
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	branch, ok := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: refs/heads/")
+	if !ok {
+		return "" // A detached HEAD holds a commit instead
+	}
+	return branch
+}
+
+// findGitDir looks for the .git directory of the repository dir is in. In
+// a linked worktree .git is a file pointing at the real one.
+func findGitDir(dir string) (string, bool) {
+	if dir == "" {
+		return "", false
+	}
+	dir = filepath.Clean(dir)
+	for {
+		path := filepath.Join(dir, ".git")
+		if info, err := os.Stat(path); err == nil {
+			if info.IsDir() {
+				return path, true
+			}
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				return "", false
+			}
+			gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(raw)), "gitdir: ")
+			if !ok {
+				return "", false
+			}
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(dir, gitDir)
+			}
+			return gitDir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Branches remembers the branch checked out for each directory looked up,
+// so filtering a list reads each .git/HEAD once
+type Branches map[string]string
+
+// Of returns the branch checked out in the repository dir is in
+func (b Branches) Of(dir string) string {
+	branch, ok := b[dir]
+	if !ok {
+		branch = CurrentBranch(dir)
+		b[dir] = branch
+	}
+	return branch
+}
+
+// Visible reports whether a task shows with the branches checked out now:
+// tasks tied to a branch only show while it is checked out where they
+// belong, as seen from co
+func (b Branches) Visible(t Todo, co Checkout) bool {
+	return t.Branch == "" || b.Of(t.ContextIn(co)) == t.Branch
+}
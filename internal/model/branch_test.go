@@ -0,0 +1,74 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCurrentBranch(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "cmd", "api")
+	for _, dir := range []string{filepath.Join(root, ".git"), sub} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeHead := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := CurrentBranch(t.TempDir()); got != "" {
+		t.Errorf("CurrentBranch outside a repository = %q", got)
+	}
+
+	writeHead(filepath.Join(root, ".git", "HEAD"), "ref: refs/heads/feature/login\n")
+	if got := CurrentBranch(sub); got != "feature/login" {
+		t.Errorf("CurrentBranch(sub) = %q, want feature/login", got)
+	}
+
+	writeHead(filepath.Join(root, ".git", "HEAD"), "3f1c2d4e5f60718293a4b5c6d7e8f90112233445\n")
+	if got := CurrentBranch(sub); got != "" {
+		t.Errorf("CurrentBranch with a detached HEAD = %q", got)
+	}
+
+	// A linked worktree's .git file points at its own HEAD
+	worktree := t.TempDir()
+	gitDir := filepath.Join(root, ".git", "worktrees", "wt")
+	if err := os.MkdirAll(gitDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeHead(filepath.Join(gitDir, "HEAD"), "ref: refs/heads/hotfix\n")
+	writeHead(filepath.Join(worktree, ".git"), "gitdir: "+gitDir+"\n")
+	if got := CurrentBranch(worktree); got != "hotfix" {
+		t.Errorf("CurrentBranch in a worktree = %q, want hotfix", got)
+	}
+}
+
+func TestBranchesVisible(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := Branches{}
+	for _, tt := range []struct {
+		todo Todo
+		want bool
+	}{
+		{Todo{Context: root}, true},
+		{Todo{Context: root, Branch: "main"}, true},
+		{Todo{Context: root, Branch: "feature"}, false},
+		{Todo{Branch: "main"}, false},
+	} {
+		if got := b.Visible(tt.todo, Checkout{}); got != tt.want {
+			t.Errorf("Visible(%+v) = %v, want %v", tt.todo, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// SetContext moves the task to another directory, dropping its anchor to
+// a repository
+func (t *Todo) SetContext(context string) {
+	t.Context = context
+	t.Repo = ""
+	t.RepoPath = ""
+}
+
+// Checkout is a git repository as checked out in one place, a clone or a
+// worktree. Tasks anchored to the repository belong in it wherever it is.
+type Checkout struct {
+	Repo string // ID of the repository, as in Todo.Repo; "" for none
+	Root string // Its top directory here
+}
+
+// locate returns the directory a task belongs in, as seen from co: the
+// same place inside co for a task anchored to co's repository, otherwise
+// the task's context
+func (co Checkout) locate(context, repo, repoPath string) string {
+	if co.Repo == "" || repo != co.Repo {
+		return context
+	}
+	return filepath.Join(co.Root, filepath.FromSlash(repoPath))
+}
+
+// ContextIn returns the directory the task belongs in, as seen from co
+func (t Todo) ContextIn(co Checkout) string {
+	return co.locate(t.Context, t.Repo, t.RepoPath)
+}
+
+// ContextIn returns the directory the task belongs in, as seen from co
+func (a ArchivedTodo) ContextIn(co Checkout) string {
+	return co.locate(a.Context, a.Repo, a.RepoPath)
+}
+
+// Anchor ties tasks to the git repository repo, checked out at root, so
+// they can be found wherever it is moved or cloned: tasks with a context
+// inside root that aren't anchored yet are anchored to it. Contexts are
+// left as they are; see ContextIn. It reports whether any task changed.
+func (d *Data) Anchor(repo, root string) bool {
+	changed := false
+	for i := range d.Items {
+		changed = d.Items[i].Anchor(repo, root) || changed
+	}
+	for i := range d.Archive {
+		changed = d.Archive[i].Anchor(repo, root) || changed
+	}
+	return changed
+}
+
+// Anchor ties the task to the git repository repo, checked out at root, if
+// it isn't anchored and its context is inside root. It reports whether it
+// did.
+func (t *Todo) Anchor(repo, root string) bool {
+	return anchor(t.Context, &t.Repo, &t.RepoPath, repo, root)
+}
+
+// Anchor ties the task to the git repository repo, checked out at root, if
+// it isn't anchored and its context is inside root. It reports whether it
+// did.
+func (a *ArchivedTodo) Anchor(repo, root string) bool {
+	return anchor(a.Context, &a.Repo, &a.RepoPath, repo, root)
+}
+
+func anchor(context string, taskRepo, repoPath *string, repo, root string) bool {
+	if *taskRepo != "" || context == "" {
+		return false
+	}
+	rel, ok := within(root, context)
+	if !ok {
+		return false
+	}
+	*taskRepo, *repoPath = repo, rel
+	return true
+}
+
+// Relink moves the tasks in directory old and below to the same place in
+// dir, and returns how many moved. co is the checkout dir is in, if any:
+// tasks anchored to its repository get their place in it recomputed, and
+// ones anchored to any other lose the anchor, which no longer says where
+// they belong.
+func (d *Data) Relink(old, dir string, co Checkout) int {
+	n := 0
+	for i := range d.Items {
+		if d.Items[i].Relink(old, dir, co) {
+			n++
+		}
+	}
+	for i := range d.Archive {
+		if d.Archive[i].Relink(old, dir, co) {
+			n++
+		}
+	}
+	return n
+}
+
+// Relink moves the task to the same place in dir if it is in directory
+// old or below, and reports whether it did; see Data.Relink
+func (t *Todo) Relink(old, dir string, co Checkout) bool {
+	return relink(&t.Context, &t.Repo, &t.RepoPath, old, dir, co)
+}
+
+// Relink moves the task to the same place in dir if it is in directory
+// old or below, and reports whether it did; see Data.Relink
+func (a *ArchivedTodo) Relink(old, dir string, co Checkout) bool {
+	return relink(&a.Context, &a.Repo, &a.RepoPath, old, dir, co)
+}
+
+func relink(context, taskRepo, repoPath *string, old, dir string, co Checkout) bool {
+	rel, ok := within(old, *context)
+	if !ok {
+		return false
+	}
+	*context = filepath.Join(dir, filepath.FromSlash(rel))
+	if *taskRepo == "" {
+		return true
+	}
+	if inRepo, ok := within(co.Root, *context); ok && co.Repo == *taskRepo {
+		*repoPath = inRepo
+	} else {
+		*taskRepo, *repoPath = "", ""
+	}
+	return true
+}
+
+// within returns the slash-separated path of context inside dir, "" for
+// dir itself, and whether it is inside at all
+func within(dir, context string) (string, bool) {
+	dir, context = filepath.Clean(dir), filepath.Clean(context)
+	if context == dir {
+		return "", true
+	}
+	if rel, ok := strings.CutPrefix(context, dir+string(filepath.Separator)); ok {
+		return filepath.ToSlash(rel), true
+	}
+	return "", false
+}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnchor(t *testing.T) {
+	d := NewData()
+	d.Items = []Todo{
+		{ID: "root", Context: "/src/api"},
+		{ID: "sub", Context: "/src/api/cmd"},
+		{ID: "other", Context: "/src/apiary"},
+		{ID: "global"},
+	}
+	d.Archive = []ArchivedTodo{{ID: "done", Context: "/src/api/docs"}}
+
+	if !d.Anchor("github.com/me/api", "/src/api") {
+		t.Fatal("Anchor() reported no change")
+	}
+	for _, tt := range []struct {
+		repo, path string
+		item       Todo
+	}{
+		{"github.com/me/api", "", d.Items[0]},
+		{"github.com/me/api", "cmd", d.Items[1]},
+		{"", "", d.Items[2]},
+		{"", "", d.Items[3]},
+	} {
+		if tt.item.Repo != tt.repo || tt.item.RepoPath != tt.path {
+			t.Errorf("%s anchored to %q %q, want %q %q", tt.item.ID, tt.item.Repo, tt.item.RepoPath, tt.repo, tt.path)
+		}
+	}
+	if d.Archive[0].RepoPath != "docs" {
+		t.Errorf("completed task anchored at %q, want docs", d.Archive[0].RepoPath)
+	}
+	if d.Anchor("github.com/me/api", "/src/api") {
+		t.Error("Anchor() again reported a change")
+	}
+
+	// Cloned somewhere else, the tasks are found there, while their
+	// contexts stay as they were added
+	clone := Checkout{Repo: "github.com/me/api", Root: "/home/me/work/api"}
+	if d.Anchor(clone.Repo, clone.Root) {
+		t.Error("Anchor() in a clone reported a change")
+	}
+	if d.Items[1].Context != "/src/api/cmd" {
+		t.Errorf("anchoring in a clone rewrote the context to %q", d.Items[1].Context)
+	}
+	if d.Items[1].ContextIn(clone) != "/home/me/work/api/cmd" || d.Archive[0].ContextIn(clone) != "/home/me/work/api/docs" {
+		t.Errorf("contexts in the clone = %q, %q", d.Items[1].ContextIn(clone), d.Archive[0].ContextIn(clone))
+	}
+	if d.Items[2].ContextIn(clone) != "/src/apiary" {
+		t.Errorf("task outside the repository moved to %q", d.Items[2].ContextIn(clone))
+	}
+	var got []string
+	for _, item := range d.FilterByContext("/home/me/work/api/cmd", clone) {
+		got = append(got, item.ID)
+	}
+	if strings.Join(got, " ") != "root sub global" {
+		t.Errorf("FilterByContext() in the clone = %v, want root sub global", got)
+	}
+	if n := len(d.FilterArchiveByContext("/home/me/work/api", clone)); n != 1 {
+		t.Errorf("FilterArchiveByContext() in the clone found %d, want the completed task", n)
+	}
+}
+
+func TestRelink(t *testing.T) {
+	api := Checkout{Repo: "github.com/me/api", Root: "/work/api"}
+	d := NewData()
+	d.Items = []Todo{
+		{ID: "a", Context: "/src/api", Repo: api.Repo},
+		{ID: "b", Context: "/src/api/cmd"},
+		{ID: "c", Context: "/src/apiary"},
+		{ID: "e", Context: "/src/api/lib", Repo: api.Repo, RepoPath: "lib"},
+	}
+	d.Archive = []ArchivedTodo{{ID: "d", Context: "/src/api/docs", Repo: api.Repo, RepoPath: "docs"}}
+
+	if n := d.Relink("/src/api/", "/work/api", api); n != 4 {
+		t.Errorf("Relink() = %d, want 4", n)
+	}
+	want := []string{"/work/api", "/work/api/cmd", "/src/apiary", "/work/api/lib"}
+	for i, item := range d.Items {
+		if item.Context != want[i] {
+			t.Errorf("%s context = %q, want %q", item.ID, item.Context, want[i])
+		}
+	}
+	if d.Items[0].Repo != api.Repo || d.Items[3].RepoPath != "lib" {
+		t.Errorf("relinked tasks lost their anchors")
+	}
+	if d.Archive[0].Context != "/work/api/docs" || d.Archive[0].RepoPath != "docs" {
+		t.Errorf("completed task at %q, %q in the repository", d.Archive[0].Context, d.Archive[0].RepoPath)
+	}
+
+	// Moved within the repository, the place in it follows
+	if n := d.Relink("/work/api/lib", "/work/api/pkg/lib", api); n != 1 {
+		t.Errorf("Relink() within the repository = %d, want 1", n)
+	}
+	if d.Items[3].RepoPath != "pkg/lib" || d.Items[3].ContextIn(api) != "/work/api/pkg/lib" {
+		t.Errorf("task moved within the repository is at %q", d.Items[3].RepoPath)
+	}
+
+	// Moved out of it, the anchor goes
+	if n := d.Relink("/work/api/pkg", "/scratch/pkg", Checkout{}); n != 1 {
+		t.Errorf("Relink() out of the repository = %d, want 1", n)
+	}
+	if d.Items[3].Repo != "" || d.Items[3].RepoPath != "" || d.Items[3].ContextIn(api) != "/scratch/pkg/lib" {
+		t.Errorf("task moved out of the repository kept its anchor: %+v", d.Items[3])
+	}
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StartOfDay returns midnight at the start of t's day in t's location
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// ParseDate resolves a user-supplied day relative to now. The result is
+// midnight at the start of that day, in now's location. Understood forms:
+//
+//	today, tod, tomorrow, tom, yesterday
+//	mon, monday, ...      the next such day, never today
+//	next mon              a week after "mon"
+//	next week             Monday of next week
+//	next month            the 1st of next month
+//	in 3d, in 2 weeks     days (d), weeks (w) or months (m) from today; "+3d" also works
+//	eow, eom              Friday of this week, last day of this month
+//	2026-11-02            an ISO date
+//
+// now is passed in rather than read from the clock so callers and tests
+// control what "today" means.
+func ParseDate(s string, now time.Time) (time.Time, error) {
+	input := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	today := StartOfDay(now)
+
+	switch input {
+	case "":
+		return time.Time{}, fmt.Errorf("empty date")
+	case "today", "tod":
+		return today, nil
+	case "tomorrow", "tom":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "next week":
+		return today.AddDate(0, 0, daysUntil(today, time.Monday)), nil
+	case "next month":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), nil
+	case "eow":
+		days := (int(time.Friday) - int(today.Weekday()) + 7) % 7
+		return today.AddDate(0, 0, days), nil
+	case "eom":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()), nil
+	}
+
+	if wd, ok := parseWeekday(input); ok {
+		return today.AddDate(0, 0, daysUntil(today, wd)), nil
+	}
+	if rest, ok := strings.CutPrefix(input, "next "); ok {
+		if wd, ok := parseWeekday(rest); ok {
+			return today.AddDate(0, 0, daysUntil(today, wd)+7), nil
+		}
+	}
+
+	if rest, ok := strings.CutPrefix(input, "in "); ok {
+		if t, ok := addOffset(today, rest); ok {
+			return t, nil
+		}
+	}
+	if rest, ok := strings.CutPrefix(input, "+"); ok {
+		if t, ok := addOffset(today, rest); ok {
+			return t, nil
+		}
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", input, now.Location()); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("can't understand date %q", s)
+}
+
+// ParsePastDay resolves "today", "yesterday" or a weekday name to midnight
+// at the start of the most recent such day, which may be today. It is the
+// backward-looking counterpart of ParseDate, for ranges like "since monday".
+func ParsePastDay(s string, now time.Time) (time.Time, bool) {
+	input := strings.ToLower(strings.TrimSpace(s))
+	today := StartOfDay(now)
+
+	switch input {
+	case "today", "tod":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+	if wd, ok := parseWeekday(input); ok {
+		days := (int(today.Weekday()) - int(wd) + 7) % 7
+		return today.AddDate(0, 0, -days), true
+	}
+	return time.Time{}, false
+}
+
+// parseWeekday matches full ("friday") and short ("fri") weekday names
+func parseWeekday(s string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if s == name || s == name[:3] {
+			return wd, true
+		}
+	}
+	return 0, false
+}
+
+// daysUntil returns how many days after today the next wd falls (1-7)
+func daysUntil(today time.Time, wd time.Weekday) int {
+	days := (int(wd) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return days
+}
+
+// addOffset applies an offset such as "3d", "3 days", "2w" or "1m" to today
+func addOffset(today time.Time, s string) (time.Time, bool) {
+	s = strings.ReplaceAll(s, " ", "")
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	n, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	switch s[i:] {
+	case "d", "day", "days":
+		return today.AddDate(0, 0, n), true
+	case "w", "wk", "week", "weeks":
+		return today.AddDate(0, 0, 7*n), true
+	case "m", "mo", "month", "months":
+		return today.AddDate(0, n, 0), true
+	}
+	return time.Time{}, false
+}
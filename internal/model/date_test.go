@@ -0,0 +1,190 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"today", day(2026, 10, 14)},
+		{"tod", day(2026, 10, 14)},
+		{"  Today ", day(2026, 10, 14)},
+		{"tomorrow", day(2026, 10, 15)},
+		{"tom", day(2026, 10, 15)},
+		{"yesterday", day(2026, 10, 13)},
+
+		// Weekdays always resolve to a future day
+		{"thursday", day(2026, 10, 15)},
+		{"fri", day(2026, 10, 16)},
+		{"Friday", day(2026, 10, 16)},
+		{"sun", day(2026, 10, 18)},
+		{"monday", day(2026, 10, 19)},
+		{"wed", day(2026, 10, 21)},
+		{"tue", day(2026, 10, 20)},
+		{"next fri", day(2026, 10, 23)},
+		{"next  wednesday", day(2026, 10, 28)},
+
+		{"next week", day(2026, 10, 19)},
+		{"next month", day(2026, 11, 1)},
+
+		{"in 3d", day(2026, 10, 17)},
+		{"in 3 days", day(2026, 10, 17)},
+		{"in 1 day", day(2026, 10, 15)},
+		{"in 0d", day(2026, 10, 14)},
+		{"in 2w", day(2026, 10, 28)},
+		{"in 2 weeks", day(2026, 10, 28)},
+		{"in 1m", day(2026, 11, 14)},
+		{"in 3 months", day(2027, 1, 14)},
+		{"+10d", day(2026, 10, 24)},
+
+		{"eow", day(2026, 10, 16)},
+		{"eom", day(2026, 10, 31)},
+
+		{"2026-11-02", day(2026, 11, 2)},
+		{"2027-02-28", day(2027, 2, 28)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDate(tt.input, now)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %s, want %s", tt.input, got.Format("Mon 2006-01-02"), tt.want.Format("Mon 2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestParseDateBoundaries(t *testing.T) {
+	tests := []struct {
+		name  string
+		now   time.Time
+		input string
+		want  time.Time
+	}{
+		{
+			name:  "eom in february of a leap year",
+			now:   time.Date(2028, 2, 10, 9, 0, 0, 0, time.UTC),
+			input: "eom",
+			want:  time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "eom on the last day",
+			now:   time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC),
+			input: "eom",
+			want:  time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "tomorrow across a year boundary",
+			now:   time.Date(2026, 12, 31, 8, 0, 0, 0, time.UTC),
+			input: "tomorrow",
+			want:  time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "next month in december",
+			now:   time.Date(2026, 12, 5, 8, 0, 0, 0, time.UTC),
+			input: "next month",
+			want:  time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "same weekday is a week away",
+			now:   time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC), // Friday
+			input: "friday",
+			want:  time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "eow on a saturday rolls to next friday",
+			now:   time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC),
+			input: "eow",
+			want:  time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "next week on a sunday",
+			now:   time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC),
+			input: "next week",
+			want:  time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "result keeps the clock's location",
+			now:   time.Date(2026, 10, 14, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*3600)),
+			input: "tomorrow",
+			want:  time.Date(2026, 10, 15, 0, 0, 0, 0, time.FixedZone("UTC-5", -5*3600)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.input, tt.now)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateErrors(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	for _, input := range []string{
+		"",
+		"   ",
+		"someday",
+		"next",
+		"next year",
+		"in",
+		"in d",
+		"in 3",
+		"in 3 fortnights",
+		"+",
+		"2026-13-01",
+		"2026/11/02",
+		"frid",
+	} {
+		t.Run(input, func(t *testing.T) {
+			if got, err := ParseDate(input, now); err == nil {
+				t.Errorf("ParseDate(%q) = %s, want error", input, got)
+			}
+		})
+	}
+}
+
+func TestParsePastDay(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 45, 0, 0, time.UTC) // Friday
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"today", day(16)},
+		{"yesterday", day(15)},
+		{"monday", day(12)},
+		{"Mon", day(12)},
+		{"fri", day(16)},
+		{"sat", day(10)},
+	}
+	for _, tt := range tests {
+		got, ok := ParsePastDay(tt.input, now)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("ParsePastDay(%q) = %s, %v, want %s", tt.input, got.Format("Mon Jan 2"), ok, tt.want.Format("Mon Jan 2"))
+		}
+	}
+	if _, ok := ParsePastDay("next week", now); ok {
+		t.Error("ParsePastDay(\"next week\") should fail")
+	}
+}
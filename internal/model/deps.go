@@ -0,0 +1,126 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsBlocked reports whether any of the task's blockers are still active
+func (d *Data) IsBlocked(t Todo) bool {
+	return len(d.Blockers(t)) > 0
+}
+
+// Blockers returns the active tasks that t is waiting on
+func (d *Data) Blockers(t Todo) []Todo {
+	var blockers []Todo
+	for _, id := range t.BlockedBy {
+		for _, item := range d.Items {
+			if item.ID == id {
+				blockers = append(blockers, item)
+				break
+			}
+		}
+	}
+	return blockers
+}
+
+// Dependents returns the active tasks waiting on the task with the given ID
+func (d *Data) Dependents(id string) []Todo {
+	var dependents []Todo
+	for _, item := range d.Items {
+		for _, blocker := range item.BlockedBy {
+			if blocker == id {
+				dependents = append(dependents, item)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// JoinTexts lists the texts of todos, such as a task's blockers
+func JoinTexts(todos []Todo) string {
+	texts := make([]string, len(todos))
+	for i, todo := range todos {
+		texts[i] = todo.Text
+	}
+	return strings.Join(texts, ", ")
+}
+
+// AddBlocker records that the task at index i is blocked by the task at
+// index j. It fails if that would make a task wait on itself, directly or
+// through a chain of other tasks.
+func (d *Data) AddBlocker(i, j int) error {
+	task, blocker := d.Items[i], d.Items[j]
+	if task.ID == blocker.ID {
+		return fmt.Errorf("a task can't block itself")
+	}
+	if d.waitsOn(blocker.ID, task.ID, map[string]bool{}) {
+		return fmt.Errorf("%q already waits on %q; blocking would create a cycle", blocker.Text, task.Text)
+	}
+	for _, id := range task.BlockedBy {
+		if id == blocker.ID {
+			return nil
+		}
+	}
+	d.Items[i].BlockedBy = append(d.Items[i].BlockedBy, blocker.ID)
+	return nil
+}
+
+// RemoveBlocker drops blockerID from the blockers of the task at index i
+func (d *Data) RemoveBlocker(i int, blockerID string) {
+	d.Items[i].BlockedBy = removeID(d.Items[i].BlockedBy, blockerID)
+}
+
+// Unblock removes id from every task's blockers, once it is dropped. A
+// completed task stays listed, so its dependents wait on it again if it
+// is uncompleted.
+func (d *Data) Unblock(id string) {
+	for i := range d.Items {
+		d.Items[i].BlockedBy = removeID(d.Items[i].BlockedBy, id)
+	}
+}
+
+// SortBlockedLast moves blocked tasks below actionable ones, keeping the
+// order within each group
+func (d *Data) SortBlockedLast(items []Todo) []Todo {
+	var actionable, blocked []Todo
+	for _, item := range items {
+		if d.IsBlocked(item) {
+			blocked = append(blocked, item)
+		} else {
+			actionable = append(actionable, item)
+		}
+	}
+	return append(actionable, blocked...)
+}
+
+// waitsOn reports whether the task with ID from is blocked, directly or
+// transitively, by the task with ID target
+func (d *Data) waitsOn(from, target string, seen map[string]bool) bool {
+	if seen[from] {
+		return false
+	}
+	seen[from] = true
+	for _, item := range d.Items {
+		if item.ID != from {
+			continue
+		}
+		for _, id := range item.BlockedBy {
+			if id == target || d.waitsOn(id, target, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func removeID(ids []string, id string) []string {
+	var kept []string
+	for _, existing := range ids {
+		if existing != id {
+			kept = append(kept, existing)
+		}
+	}
+	return kept
+}
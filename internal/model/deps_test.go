@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddBlockerRejectsCycles(t *testing.T) {
+	d := NewData()
+	d.Items = []Todo{{ID: "a", Text: "deploy"}, {ID: "b", Text: "review"}, {ID: "c", Text: "write"}}
+
+	// deploy waits on review, which waits on write
+	if err := d.AddBlocker(0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.AddBlocker(1, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.AddBlocker(2, 0); err == nil {
+		t.Error("write waiting on deploy should be rejected as a cycle")
+	}
+	if err := d.AddBlocker(1, 1); err == nil {
+		t.Error("a task blocking itself should be rejected")
+	}
+	if err := d.AddBlocker(0, 1); err != nil || len(d.Items[0].BlockedBy) != 1 {
+		t.Errorf("adding an existing blocker should be a no-op, got %v, %v", err, d.Items[0].BlockedBy)
+	}
+}
+
+func TestCompleteUnblocksDependents(t *testing.T) {
+	d := NewData()
+	d.Items = []Todo{
+		{ID: "a", Text: "deploy", BlockedBy: []string{"b", "c"}},
+		{ID: "b", Text: "review"},
+		{ID: "c", Text: "write"},
+		{ID: "d", Text: "announce", BlockedBy: []string{"a"}},
+	}
+
+	if got := d.SortBlockedLast(d.Items); got[0].ID != "b" || got[1].ID != "c" || got[2].ID != "a" || got[3].ID != "d" {
+		t.Errorf("blocked tasks should sort last in their original order, got %v", got)
+	}
+
+	d.Complete(2, time.Now())
+	if !d.IsBlocked(d.Items[0]) {
+		t.Error("deploy is still waiting on review")
+	}
+	d.Complete(1, time.Now())
+	if d.IsBlocked(d.Items[0]) {
+		t.Errorf("deploy should be unblocked once its blockers are done, blocked by %v", d.Blockers(d.Items[0]))
+	}
+	if !d.IsBlocked(d.Items[1]) {
+		t.Error("announce still waits on deploy")
+	}
+
+	// Uncompleting a blocker blocks its dependents again
+	review := d.Archive[1]
+	d.Archive = d.Archive[:1]
+	d.Reopen(review)
+	if blockers := d.Blockers(d.Items[1]); len(blockers) != 1 || blockers[0].ID != "b" {
+		t.Errorf("deploy should wait on review again, blocked by %v", blockers)
+	}
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Estimate is how much work a task is expected to take, either as a
+// length of time or in effort points. Only one of the two is set.
+type Estimate struct {
+	Minutes int
+	Points  int
+}
+
+// ParseEstimate parses an estimate such as "30m", "1h30m", "2h" or "3pt".
+// Bare numbers are rejected since they could mean either.
+func ParseEstimate(s string) (Estimate, error) {
+	input := strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	if input == "" {
+		return Estimate{}, fmt.Errorf("empty estimate")
+	}
+
+	for _, suffix := range []string{"points", "point", "pts", "pt", "p"} {
+		if rest, ok := strings.CutSuffix(input, suffix); ok {
+			n, err := strconv.Atoi(rest)
+			if err != nil || n <= 0 {
+				return Estimate{}, fmt.Errorf("invalid estimate %q: points must be a whole number above 0", s)
+			}
+			return Estimate{Points: n}, nil
+		}
+	}
+
+	d, err := time.ParseDuration(input)
+	if err != nil {
+		if _, err := strconv.Atoi(input); err == nil {
+			return Estimate{}, fmt.Errorf("invalid estimate %q: add a unit, e.g. 30m or 3pt", s)
+		}
+		return Estimate{}, fmt.Errorf("invalid estimate %q (use e.g. 30m, 1h30m or 3pt)", s)
+	}
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes <= 0 {
+		return Estimate{}, fmt.Errorf("invalid estimate %q: must be at least a minute", s)
+	}
+	return Estimate{Minutes: minutes}, nil
+}
+
+// Duration returns the estimated time, zero for point estimates
+func (e Estimate) Duration() time.Duration {
+	return time.Duration(e.Minutes) * time.Minute
+}
+
+// String returns the canonical form of the estimate, which ParseEstimate
+// reads back: "45m", "1h30m" or "3pt"
+func (e Estimate) String() string {
+	switch {
+	case e.Points > 0:
+		return fmt.Sprintf("%dpt", e.Points)
+	case e.Minutes >= 60 && e.Minutes%60 == 0:
+		return fmt.Sprintf("%dh", e.Minutes/60)
+	case e.Minutes >= 60:
+		return fmt.Sprintf("%dh%dm", e.Minutes/60, e.Minutes%60)
+	case e.Minutes > 0:
+		return fmt.Sprintf("%dm", e.Minutes)
+	}
+	return ""
+}
+
+// GetEstimate returns the task's parsed estimate, zero when unset
+func (t Todo) GetEstimate() Estimate {
+	e, _ := ParseEstimate(t.Estimate)
+	return e
+}
+
+// GetEstimate returns the task's parsed estimate, zero when unset
+func (a ArchivedTodo) GetEstimate() Estimate {
+	e, _ := ParseEstimate(a.Estimate)
+	return e
+}
+
+// SumEstimates adds up the estimates of todos, keeping time and points apart
+func SumEstimates(todos []Todo) Estimate {
+	var sum Estimate
+	for _, t := range todos {
+		e := t.GetEstimate()
+		sum.Minutes += e.Minutes
+		sum.Points += e.Points
+	}
+	return sum
+}
@@ -0,0 +1,43 @@
+package model
+
+import "testing"
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Estimate
+		str   string
+	}{
+		{"30m", Estimate{Minutes: 30}, "30m"},
+		{"1h30m", Estimate{Minutes: 90}, "1h30m"},
+		{"1h 30m", Estimate{Minutes: 90}, "1h30m"},
+		{"2h", Estimate{Minutes: 120}, "2h"},
+		{"90m", Estimate{Minutes: 90}, "1h30m"},
+		{"3pt", Estimate{Points: 3}, "3pt"},
+		{"5 points", Estimate{Points: 5}, "5pt"},
+		{"1P", Estimate{Points: 1}, "1pt"},
+	}
+	for _, tt := range tests {
+		got, err := ParseEstimate(tt.input)
+		if err != nil {
+			t.Errorf("ParseEstimate(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want || got.String() != tt.str {
+			t.Errorf("ParseEstimate(%q) = %+v (%s), want %+v (%s)", tt.input, got, got, tt.want, tt.str)
+		}
+	}
+
+	for _, input := range []string{"", "30", "soon", "0m", "20s", "0pt", "-2pt", "1.5pt"} {
+		if _, err := ParseEstimate(input); err == nil {
+			t.Errorf("ParseEstimate(%q) expected error", input)
+		}
+	}
+}
+
+func TestSumEstimates(t *testing.T) {
+	todos := []Todo{{Estimate: "30m"}, {Estimate: "1h"}, {Estimate: "2pt"}, {}, {Estimate: "3pt"}}
+	if got := SumEstimates(todos); got != (Estimate{Minutes: 90, Points: 5}) {
+		t.Errorf("SumEstimates = %+v", got)
+	}
+}
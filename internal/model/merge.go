@@ -0,0 +1,377 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Side is one of the two versions of the data a merge combines
+type Side int
+
+const (
+	Ours   Side = iota // The local version
+	Theirs             // The version being merged in
+)
+
+// Conflict is a change both sides made to a task in different ways, and
+// which side the merge kept
+type Conflict struct {
+	ID    string
+	Text  string // Text of the task as merged
+	Field string // JSON name of the field, or "status" when one side completed the task and the other didn't
+	Kept  Side
+}
+
+// Edits records when each field of a task was last changed, by the
+// field's JSON name, so a merge can keep whichever clashing edit was made
+// last
+type Edits map[string]time.Time
+
+// StampEdits records now as the edit time of each field of a task that
+// differs from the task's version in before. Tasks new since before aren't
+// stamped; no other version has them to clash with.
+func (d *Data) StampEdits(before *Data, now time.Time) {
+	prev := map[string]any{}
+	for _, item := range before.Items {
+		prev[item.ID] = item
+	}
+	for _, item := range before.Archive {
+		prev[item.ID] = item
+	}
+	for i, item := range d.Items {
+		if p, ok := prev[item.ID]; ok {
+			d.Items[i].Edited = stampEdits(item.Edited, p, item, now)
+		}
+	}
+	for i, item := range d.Archive {
+		if p, ok := prev[item.ID]; ok {
+			d.Archive[i].Edited = stampEdits(item.Edited, p, item, now)
+		}
+	}
+}
+
+// stampEdits returns edits with now recorded for the fields that differ
+// between the versions prev and v of a task, apart from where it sits
+func stampEdits(edits Edits, prev, v any, now time.Time) Edits {
+	if reflect.DeepEqual(prev, v) {
+		return edits
+	}
+	before, after := JSONFields(prev), JSONFields(v)
+	stamped := maps.Clone(edits)
+	for _, fields := range []map[string]json.RawMessage{before, after} {
+		for name := range fields {
+			if name == "position" || name == "edited" || bytes.Equal(before[name], after[name]) {
+				continue
+			}
+			if stamped == nil {
+				stamped = Edits{}
+			}
+			stamped[name] = now
+		}
+	}
+	return stamped
+}
+
+// taskState is a task as one version of the data has it
+type taskState int
+
+const (
+	stateNone taskState = iota // Not there, or deleted
+	stateActive
+	stateDone
+)
+
+// taskVersion is a task in one version of the data, as JSON fields so
+// active and completed tasks can be compared field by field
+type taskVersion struct {
+	state  taskState
+	fields map[string]json.RawMessage
+}
+
+// Merge combines two versions of the data that both changed base. Each
+// task is merged on its own, keyed by ID: tasks added on either side are
+// kept, a change made on one side only is taken, and completing a task
+// wins over it staying active. A task deleted on one side and edited on
+// the other is kept, with the edit, and the clash reported. When both
+// sides changed the same field in different ways, the change made last by
+// the task's Edited times is kept, ours when neither is later, and the
+// clash reported.
+func Merge(base, ours, theirs *Data) (*Data, []Conflict) {
+	b, o, t := taskVersions(base), taskVersions(ours), taskVersions(theirs)
+
+	merged := NewData()
+	merged.Version = ours.Version
+	var conflicts []Conflict
+	done := map[string]ArchivedTodo{}
+	active := map[string]Todo{}
+
+	for _, id := range mergeIDs(ours, theirs) {
+		state, clash := mergeState(b[id].state, o[id].state, t[id].state)
+		if state == stateNone && b[id].state != stateNone {
+			// Deleted on one side; keep the task if the other edited it
+			for _, v := range []taskVersion{o[id], t[id]} {
+				if v.state != stateNone && edited(b[id].fields, v.fields) {
+					state, clash = v.state, true
+				}
+			}
+		}
+		if state == stateNone {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		var clashes []Conflict
+		switch {
+		case o[id].state == stateNone:
+			fields = t[id].fields
+		case t[id].state == stateNone:
+			fields = o[id].fields
+		default:
+			fields, clashes = mergeFields(b[id].fields, o[id].fields, t[id].fields)
+		}
+		raw, _ := json.Marshal(fields)
+
+		var text string
+		if state == stateDone {
+			var item ArchivedTodo
+			_ = json.Unmarshal(raw, &item)
+			done[id] = item
+			text = item.Text
+		} else {
+			var item Todo
+			_ = json.Unmarshal(raw, &item)
+			active[id] = item
+			text = item.Text
+		}
+
+		if clash {
+			kept := Ours
+			if state == t[id].state {
+				kept = Theirs
+			}
+			conflicts = append(conflicts, Conflict{ID: id, Text: text, Field: "status", Kept: kept})
+		}
+		for _, c := range clashes {
+			c.ID, c.Text = id, text
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	for _, id := range mergeOrder(todoOrder(base), todoOrder(ours), todoOrder(theirs)) {
+		if item, ok := active[id]; ok {
+			item.Position = len(merged.Items)
+			merged.Items = append(merged.Items, item)
+		}
+	}
+	for _, items := range [][]ArchivedTodo{ours.Archive, theirs.Archive} {
+		for _, item := range items {
+			if item, ok := done[item.ID]; ok {
+				merged.Archive = append(merged.Archive, item)
+				delete(done, item.ID)
+			}
+		}
+	}
+	// Tasks completed during the merge, whose active versions were edited
+	for _, id := range mergeIDs(ours, theirs) {
+		if item, ok := done[id]; ok {
+			merged.Archive = append(merged.Archive, item)
+		}
+	}
+	sort.SliceStable(merged.Archive, func(i, j int) bool {
+		return merged.Archive[i].Completed.Before(merged.Archive[j].Completed)
+	})
+
+	merged.Stats = Stats{
+		TotalCompleted: max(ours.Stats.TotalCompleted+theirs.Stats.TotalCompleted-base.Stats.TotalCompleted,
+			ours.Stats.TotalCompleted, theirs.Stats.TotalCompleted),
+		StreakDays: max(ours.Stats.StreakDays, theirs.Stats.StreakDays),
+	}
+	return merged, conflicts
+}
+
+// mergeState picks the state of a task after merging, and reports whether
+// both sides changed it in different ways
+func mergeState(base, ours, theirs taskState) (taskState, bool) {
+	switch {
+	case ours == theirs:
+		return ours, false
+	case ours == base:
+		return theirs, false
+	case theirs == base:
+		return ours, false
+	}
+	// Completed beats active, and either beats deleted
+	return max(ours, theirs), true
+}
+
+// mergeFields merges a task's fields three ways. A field changed on one
+// side only takes that change; one changed differently on both takes the
+// side whose edit of it is later, ours on a tie, and is returned in
+// clashes with the side kept.
+func mergeFields(base, ours, theirs map[string]json.RawMessage) (map[string]json.RawMessage, []Conflict) {
+	names := map[string]bool{}
+	for _, fields := range []map[string]json.RawMessage{base, ours, theirs} {
+		for name := range fields {
+			names[name] = true
+		}
+	}
+	oursEdited, theirsEdited := editsOf(ours), editsOf(theirs)
+
+	merged := map[string]json.RawMessage{}
+	var clashes []Conflict
+	for name := range names {
+		b, o, t := base[name], ours[name], theirs[name]
+		value := o
+		switch {
+		case name == "edited":
+			// Each field's latest edit, whichever side made it
+			edits := maps.Clone(oursEdited)
+			for field, at := range theirsEdited {
+				if at.After(edits[field]) {
+					if edits == nil {
+						edits = Edits{}
+					}
+					edits[field] = at
+				}
+			}
+			value, _ = json.Marshal(edits)
+			if len(edits) == 0 {
+				value = nil
+			}
+		case bytes.Equal(o, t), bytes.Equal(t, b):
+		case bytes.Equal(o, b):
+			value = t
+		case name == "position":
+			// Where the task sits is merged with the order, not here
+		default:
+			kept := Ours
+			if theirsEdited[name].After(oursEdited[name]) {
+				kept, value = Theirs, t
+			}
+			clashes = append(clashes, Conflict{Field: name, Kept: kept})
+		}
+		if value != nil {
+			merged[name] = value
+		}
+	}
+	sort.Slice(clashes, func(i, j int) bool { return clashes[i].Field < clashes[j].Field })
+	return merged, clashes
+}
+
+// editsOf reads the edit times from a task's fields
+func editsOf(fields map[string]json.RawMessage) Edits {
+	var edits Edits
+	if raw, ok := fields["edited"]; ok {
+		_ = json.Unmarshal(raw, &edits)
+	}
+	return edits
+}
+
+// edited reports whether a task's fields differ from base's, apart from
+// where it sits and when it was edited
+func edited(base, fields map[string]json.RawMessage) bool {
+	for _, names := range []map[string]json.RawMessage{base, fields} {
+		for name := range names {
+			if name != "position" && name != "edited" && !bytes.Equal(base[name], fields[name]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mergeOrder orders the merged list. It follows whichever side reordered
+// the tasks they share with base, ours when both or neither did, and fits
+// in the tasks only the other side has after the task before them there.
+func mergeOrder(base, ours, theirs []string) []string {
+	lead, other := ours, theirs
+	if reflect.DeepEqual(shared(ours, base), shared(base, ours)) && !reflect.DeepEqual(shared(theirs, base), shared(base, theirs)) {
+		lead, other = theirs, ours
+	}
+
+	order := append([]string(nil), lead...)
+	for i, id := range other {
+		if indexOfID(order, id) >= 0 {
+			continue
+		}
+		at := 0
+		if i > 0 {
+			at = indexOfID(order, other[i-1]) + 1
+		}
+		order = append(order[:at], append([]string{id}, order[at:]...)...)
+	}
+	return order
+}
+
+// shared returns the IDs in ids that are also in other, in ids' order
+func shared(ids, other []string) []string {
+	var in []string
+	for _, id := range ids {
+		if indexOfID(other, id) >= 0 {
+			in = append(in, id)
+		}
+	}
+	return in
+}
+
+func indexOfID(ids []string, id string) int {
+	for i, other := range ids {
+		if other == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func todoOrder(d *Data) []string {
+	ids := make([]string, len(d.Items))
+	for i, item := range d.Items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+// mergeIDs returns the ID of every task on either side, ours first
+func mergeIDs(ours, theirs *Data) []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, d := range []*Data{ours, theirs} {
+		for _, item := range d.Items {
+			if !seen[item.ID] {
+				seen[item.ID] = true
+				ids = append(ids, item.ID)
+			}
+		}
+		for _, item := range d.Archive {
+			if !seen[item.ID] {
+				seen[item.ID] = true
+				ids = append(ids, item.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// taskVersions maps the ID of every task in d to its version there
+func taskVersions(d *Data) map[string]taskVersion {
+	versions := map[string]taskVersion{}
+	for _, item := range d.Items {
+		versions[item.ID] = taskVersion{stateActive, JSONFields(item)}
+	}
+	for _, item := range d.Archive {
+		versions[item.ID] = taskVersion{stateDone, JSONFields(item)}
+	}
+	return versions
+}
+
+// JSONFields returns v's JSON fields by name, as encoded
+func JSONFields(v any) map[string]json.RawMessage {
+	fields := map[string]json.RawMessage{}
+	if raw, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(raw, &fields)
+	}
+	return fields
+}
@@ -0,0 +1,184 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	newBase := func() *Data {
+		d := NewData()
+		for _, id := range []string{"a", "b", "c"} {
+			d.Items = append(d.Items, Todo{ID: id, Text: "task " + id, Created: created})
+		}
+		return d
+	}
+	// summary lists the active tasks in order, then the completed ones
+	summary := func(d *Data) string {
+		var parts []string
+		for i, item := range d.Items {
+			if item.Position != i {
+				t.Errorf("%s has position %d at index %d", item.ID, item.Position, i)
+			}
+			parts = append(parts, fmt.Sprintf("%s:%s:%s", item.ID, item.Text, item.Priority))
+		}
+		parts = append(parts, "|")
+		for _, item := range d.Archive {
+			parts = append(parts, item.ID)
+		}
+		return strings.Join(parts, " ")
+	}
+
+	tests := []struct {
+		name          string
+		ours, theirs  func(d *Data)
+		want          string
+		wantConflicts string
+	}{
+		{
+			"adds on both sides",
+			func(d *Data) { d.Items = append([]Todo{{ID: "d", Text: "task d"}}, d.Items...) },
+			func(d *Data) { d.Items = append(d.Items, Todo{ID: "e", Text: "task e"}) },
+			"d:task d:Low a:task a:Low b:task b:Low c:task c:Low e:task e:Low |", "",
+		},
+		{
+			"edits to different fields",
+			func(d *Data) { d.Items[0].Text = "renamed" },
+			func(d *Data) { d.Items[0].Priority = PriorityHigh },
+			"a:renamed:High b:task b:Low c:task c:Low |", "",
+		},
+		{
+			"edits to the same field, theirs last",
+			func(d *Data) { d.Items[1].Text, d.Items[1].Edited = "ours", Edits{"text": now} },
+			func(d *Data) { d.Items[1].Text, d.Items[1].Edited = "theirs", Edits{"text": now.Add(time.Hour)} },
+			"a:task a:Low b:theirs:Low c:task c:Low |", "b text theirs",
+		},
+		{
+			"edits to the same field, ours last",
+			func(d *Data) { d.Items[1].Text, d.Items[1].Edited = "ours", Edits{"text": now.Add(time.Hour)} },
+			func(d *Data) {
+				d.Items[1].Text, d.Items[1].Edited = "theirs", Edits{"text": now, "priority": now.Add(2 * time.Hour)}
+			},
+			"a:task a:Low b:ours:Low c:task c:Low |", "b text ours",
+		},
+		{
+			"edits to the same field at unknown times",
+			func(d *Data) { d.Items[1].Text = "ours" },
+			func(d *Data) { d.Items[1].Text = "theirs" },
+			"a:task a:Low b:ours:Low c:task c:Low |", "b text ours",
+		},
+		{
+			"completed on one side, edited on the other",
+			func(d *Data) { d.Complete(0, now) },
+			func(d *Data) { d.Items[0].Text = "renamed" },
+			"b:task b:Low c:task c:Low | a", "",
+		},
+		{
+			"completed on one side, deleted on the other",
+			func(d *Data) { d.Items = d.Items[1:] },
+			func(d *Data) { d.Complete(0, now) },
+			"b:task b:Low c:task c:Low | a", "a status theirs",
+		},
+		{
+			"deleted on one side, edited on the other",
+			func(d *Data) { d.Items = d.Items[1:] },
+			func(d *Data) { d.Items[0].Text = "renamed" },
+			"a:renamed:Low b:task b:Low c:task c:Low |", "a status theirs",
+		},
+		{
+			"deleted on one side",
+			func(d *Data) {},
+			func(d *Data) { d.Items = d.Items[:2] },
+			"a:task a:Low b:task b:Low |", "",
+		},
+		{
+			"reordered on one side",
+			func(d *Data) { d.Items[1].Text = "renamed" },
+			func(d *Data) { d.MoveTodo(2, 0, false) },
+			"c:task c:Low a:task a:Low b:renamed:Low |", "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, ours, theirs := newBase(), newBase(), newBase()
+			tt.ours(ours)
+			tt.theirs(theirs)
+
+			merged, conflicts := Merge(base, ours, theirs)
+			if got := summary(merged); got != tt.want {
+				t.Errorf("Merge() = %q, want %q", got, tt.want)
+			}
+			var got []string
+			for _, c := range conflicts {
+				side := "ours"
+				if c.Kept == Theirs {
+					side = "theirs"
+				}
+				got = append(got, c.ID+" "+c.Field+" "+side)
+			}
+			if strings.Join(got, ", ") != tt.wantConflicts {
+				t.Errorf("conflicts = %q, want %q", strings.Join(got, ", "), tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestMergeStats(t *testing.T) {
+	base := NewData()
+	base.Stats = Stats{TotalCompleted: 10, StreakDays: 2}
+	ours, theirs := base.Clone(), base.Clone()
+	ours.Stats = Stats{TotalCompleted: 12, StreakDays: 3}
+	theirs.Stats = Stats{TotalCompleted: 11, StreakDays: 2}
+
+	merged, _ := Merge(base, ours, theirs)
+	if want := (Stats{TotalCompleted: 13, StreakDays: 3}); merged.Stats != want {
+		t.Errorf("Stats = %+v, want %+v", merged.Stats, want)
+	}
+}
+
+func TestStampEdits(t *testing.T) {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	first := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	before := NewData()
+	before.Items = []Todo{
+		{ID: "a", Text: "task a", Created: created},
+		{ID: "b", Text: "task b", Created: created, Position: 1},
+	}
+
+	d := before.Clone()
+	d.Items[0].Text = "renamed"
+	d.Items[0].Priority = PriorityHigh
+	d.MoveTodo(1, 0, false)
+	d.Items = append(d.Items, Todo{ID: "c", Text: "task c"})
+	d.StampEdits(before, first)
+
+	a, _ := d.FindTodo("a")
+	if want := (Edits{"text": first, "priority": first}); !reflect.DeepEqual(d.Items[a].Edited, want) {
+		t.Errorf("a edited = %v, want %v", d.Items[a].Edited, want)
+	}
+	for _, id := range []string{"b", "c"} {
+		if i, _ := d.FindTodo(id); d.Items[i].Edited != nil {
+			t.Errorf("%s edited = %v, want nothing stamped", id, d.Items[i].Edited)
+		}
+	}
+	if before.Items[0].Edited != nil {
+		t.Error("stamping changed before")
+	}
+
+	// Merging keeps each field's latest edit from either side
+	later := first.Add(time.Hour)
+	ours, theirs := d.Clone(), d.Clone()
+	ours.Items[a].Edited["text"] = later
+	theirs.Items[a].Edited["priority"] = later
+	merged, _ := Merge(d, ours, theirs)
+	i, _ := merged.FindTodo("a")
+	if want := (Edits{"text": later, "priority": later}); !reflect.DeepEqual(merged.Items[i].Edited, want) {
+		t.Errorf("merged edited = %v, want %v", merged.Items[i].Edited, want)
+	}
+}
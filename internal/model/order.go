@@ -0,0 +1,22 @@
+package model
+
+// MoveTodo moves the task at index from so it sits directly before the task
+// at index to, or directly after it when after is set. Moving relative to
+// another task rather than to an index keeps moves made in a filtered view
+// correct in the full list. Positions are renumbered to the new order.
+func (d *Data) MoveTodo(from, to int, after bool) {
+	if from != to {
+		item := d.Items[from]
+		d.Items = append(d.Items[:from], d.Items[from+1:]...)
+		if from < to {
+			to--
+		}
+		if after {
+			to++
+		}
+		d.Items = append(d.Items[:to], append([]Todo{item}, d.Items[to:]...)...)
+	}
+	for i := range d.Items {
+		d.Items[i].Position = i
+	}
+}
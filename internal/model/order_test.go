@@ -0,0 +1,43 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMoveTodo(t *testing.T) {
+	ids := func(d *Data) string {
+		var s []string
+		for i, item := range d.Items {
+			if item.Position != i {
+				t.Errorf("%s has position %d at index %d", item.ID, item.Position, i)
+			}
+			s = append(s, item.ID)
+		}
+		return strings.Join(s, "")
+	}
+
+	tests := []struct {
+		from, to int
+		after    bool
+		want     string
+	}{
+		{0, 2, false, "bacde"},
+		{0, 2, true, "bcade"},
+		{3, 1, false, "adbce"},
+		{3, 1, true, "abdce"},
+		{4, 0, false, "eabcd"},
+		{0, 4, true, "bcdea"},
+		{2, 2, false, "abcde"},
+	}
+	for _, tt := range tests {
+		d := NewData()
+		for _, id := range "abcde" {
+			d.Items = append(d.Items, Todo{ID: string(id)})
+		}
+		d.MoveTodo(tt.from, tt.to, tt.after)
+		if got := ids(d); got != tt.want {
+			t.Errorf("MoveTodo(%d, %d, %v) = %s, want %s", tt.from, tt.to, tt.after, got, tt.want)
+		}
+	}
+}
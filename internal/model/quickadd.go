@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuickAdd holds the fields parsed out of quick-add task text
+type QuickAdd struct {
+	Text        string     // Task text with all recognised tokens removed
+	Description string     // Everything after a standalone "--"
+	Priority    *Priority  // Set by !high, !med, !low, !!!, !! or !
+	Tags        []string   // Set by #tag
+	Global      bool       // Set by @global
+	Due         *time.Time // Set by due:<date>
+}
+
+// ParseQuickAdd extracts inline tokens from task text:
+//
+//	!high !med !low       priority (also !h !m !l)
+//	!!! !! !              high, medium, low priority
+//	#tag                  add a tag
+//	@global               make the task global
+//	due:fri               due date, any ParseDate form; use - for spaces (due:next-week)
+//	-- more text          the rest becomes the description
+//
+// A backslash before a token keeps it literal: \#1 stays "#1" in the text.
+func ParseQuickAdd(input string, now time.Time) (QuickAdd, error) {
+	var q QuickAdd
+	var words []string
+
+	fields := strings.Fields(input)
+	for i, word := range fields {
+		if strings.HasPrefix(word, `\`) && len(word) > 1 {
+			words = append(words, word[1:])
+			continue
+		}
+
+		switch {
+		case word == "--":
+			q.Description = strings.Join(fields[i+1:], " ")
+			q.Text = strings.Join(words, " ")
+			return q, nil
+
+		case isPriorityToken(word):
+			p := parsePriorityToken(word)
+			q.Priority = &p
+
+		case strings.HasPrefix(word, "#") && len(word) > 1:
+			q.Tags = AddTags(q.Tags, word[1:])
+
+		case word == "@global":
+			q.Global = true
+
+		case strings.HasPrefix(word, "due:"):
+			due, err := parseDueToken(word[len("due:"):], now)
+			if err != nil {
+				return q, err
+			}
+			q.Due = &due
+
+		default:
+			words = append(words, word)
+		}
+	}
+
+	q.Text = strings.Join(words, " ")
+	return q, nil
+}
+
+// HasTokens reports whether anything besides the text was parsed
+func (q QuickAdd) HasTokens() bool {
+	return q.Description != "" || q.Priority != nil || len(q.Tags) > 0 || q.Global || q.Due != nil
+}
+
+func isPriorityToken(word string) bool {
+	switch strings.ToLower(word) {
+	case "!", "!!", "!!!", "!h", "!high", "!m", "!med", "!medium", "!l", "!low":
+		return true
+	}
+	return false
+}
+
+func parsePriorityToken(word string) Priority {
+	switch strings.ToLower(word) {
+	case "!!!", "!h", "!high":
+		return PriorityHigh
+	case "!!", "!m", "!med", "!medium":
+		return PriorityMedium
+	default:
+		return PriorityLow
+	}
+}
+
+// parseDueToken parses the value of a due: token, where "-" may stand in
+// for spaces ("next-fri", "in-3d") but ISO dates keep their dashes
+func parseDueToken(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("due: needs a date, e.g. due:fri")
+	}
+	if due, err := ParseDate(value, now); err == nil {
+		return due, nil
+	}
+	return ParseDate(strings.ReplaceAll(value, "-", " "), now)
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC) // Wednesday
+	high, medium, low := PriorityHigh, PriorityMedium, PriorityLow
+	fri := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	nextWeek := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  QuickAdd
+	}{
+		{"Plain task", QuickAdd{Text: "Plain task"}},
+		{"  extra   spaces  ", QuickAdd{Text: "extra spaces"}},
+		{"Ship it !high", QuickAdd{Text: "Ship it", Priority: &high}},
+		{"!!! Ship it", QuickAdd{Text: "Ship it", Priority: &high}},
+		{"Ship it !!", QuickAdd{Text: "Ship it", Priority: &medium}},
+		{"Ship it !", QuickAdd{Text: "Ship it", Priority: &low}},
+		{"Ship it !L", QuickAdd{Text: "Ship it", Priority: &low}},
+		{"Ship it !urgent", QuickAdd{Text: "Ship it !urgent"}},
+		{"Fix #bug login #Backend #bug", QuickAdd{Text: "Fix login", Tags: []string{"bug", "backend"}}},
+		{"Call mom @global", QuickAdd{Text: "Call mom", Global: true}},
+		{"Email @bob", QuickAdd{Text: "Email @bob"}},
+		{"Report due:fri", QuickAdd{Text: "Report", Due: &fri}},
+		{"Report due:next-week", QuickAdd{Text: "Report", Due: &nextWeek}},
+		{"Report due:2026-10-16", QuickAdd{Text: "Report", Due: &fri}},
+		{"Deploy -- after the freeze ends", QuickAdd{Text: "Deploy", Description: "after the freeze ends"}},
+		{"Deploy -- keep #tags !!! here", QuickAdd{Text: "Deploy", Description: "keep #tags !!! here"}},
+		{"Use --force carefully", QuickAdd{Text: "Use --force carefully"}},
+		{`Close \#12 \!!! \@global \due:fri`, QuickAdd{Text: "Close #12 !!! @global due:fri"}},
+		{`a \-- b`, QuickAdd{Text: "a -- b"}},
+		{"#", QuickAdd{Text: "#"}},
+		{
+			"Review PR !h #review due:tom @global -- the big one",
+			QuickAdd{
+				Text:        "Review PR",
+				Description: "the big one",
+				Priority:    &high,
+				Tags:        []string{"review"},
+				Global:      true,
+				Due:         ptr(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseQuickAdd(tt.input, now)
+			if err != nil {
+				t.Fatalf("ParseQuickAdd(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuickAdd(%q)\n got  %+v\n want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuickAddErrors(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	for _, input := range []string{"Report due:", "Report due:someday"} {
+		if _, err := ParseQuickAdd(input, now); err == nil {
+			t.Errorf("ParseQuickAdd(%q) expected error", input)
+		}
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
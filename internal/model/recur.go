@@ -0,0 +1,171 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceKind identifies how a task repeats
+type RecurrenceKind int
+
+const (
+	RepeatDaily    RecurrenceKind = iota // Every day
+	RepeatWeekdays                       // Monday to Friday
+	RepeatWeekly                         // On the given weekdays
+	RepeatMonthly                        // On a day of the month
+	RepeatAfter                          // A number of days after completion
+)
+
+// Recurrence is a parsed repeat rule. Rules are stored on tasks as strings:
+//
+//	daily
+//	weekdays
+//	weekly:mon        weekly:mon,thu
+//	monthly:15        (clamped to the last day in shorter months)
+//	after:3d          3 days after the task is completed; also after:2w
+type Recurrence struct {
+	Kind     RecurrenceKind
+	Weekdays []time.Weekday // RepeatWeekly
+	Day      int            // RepeatMonthly: day of month, 1-31
+	Days     int            // RepeatAfter: days after completion
+}
+
+// ParseRecurrence parses a repeat rule
+func ParseRecurrence(s string) (Recurrence, error) {
+	rule := strings.ToLower(strings.TrimSpace(s))
+	kind, arg, _ := strings.Cut(rule, ":")
+
+	switch kind {
+	case "daily":
+		if arg == "" {
+			return Recurrence{Kind: RepeatDaily}, nil
+		}
+	case "weekdays":
+		if arg == "" {
+			return Recurrence{Kind: RepeatWeekdays}, nil
+		}
+	case "weekly":
+		r := Recurrence{Kind: RepeatWeekly}
+		for _, name := range strings.Split(arg, ",") {
+			wd, ok := parseWeekday(strings.TrimSpace(name))
+			if !ok {
+				return Recurrence{}, fmt.Errorf("weekly needs weekdays, e.g. weekly:mon,thu")
+			}
+			r.Weekdays = append(r.Weekdays, wd)
+		}
+		return r, nil
+	case "monthly":
+		day, err := strconv.Atoi(arg)
+		if err != nil || day < 1 || day > 31 {
+			return Recurrence{}, fmt.Errorf("monthly needs a day of the month, e.g. monthly:15")
+		}
+		return Recurrence{Kind: RepeatMonthly, Day: day}, nil
+	case "after":
+		if t, ok := addOffset(time.Time{}, arg); ok && arg != "" {
+			days := int(t.Sub(time.Time{}).Hours() / 24)
+			if days > 0 {
+				return Recurrence{Kind: RepeatAfter, Days: days}, nil
+			}
+		}
+		return Recurrence{}, fmt.Errorf("after needs a number of days or weeks, e.g. after:3d")
+	}
+
+	return Recurrence{}, fmt.Errorf("unknown repeat rule %q (use daily, weekdays, weekly:mon, monthly:15 or after:3d)", s)
+}
+
+// String describes the rule for display
+func (r Recurrence) String() string {
+	switch r.Kind {
+	case RepeatDaily:
+		return "daily"
+	case RepeatWeekdays:
+		return "every weekday"
+	case RepeatWeekly:
+		names := make([]string, len(r.Weekdays))
+		for i, wd := range r.Weekdays {
+			names[i] = wd.String()[:3]
+		}
+		return "weekly on " + strings.Join(names, ", ")
+	case RepeatMonthly:
+		return fmt.Sprintf("monthly on day %d", r.Day)
+	default:
+		if r.Days == 1 {
+			return "1 day after completion"
+		}
+		return fmt.Sprintf("%d days after completion", r.Days)
+	}
+}
+
+// Next returns the start of the first day after completed's day on which
+// the task occurs again
+func (r Recurrence) Next(completed time.Time) time.Time {
+	day := StartOfDay(completed)
+
+	switch r.Kind {
+	case RepeatAfter:
+		return day.AddDate(0, 0, r.Days)
+	case RepeatMonthly:
+		for months := 0; ; months++ {
+			first := time.Date(day.Year(), day.Month()+time.Month(months), 1, 0, 0, 0, 0, day.Location())
+			last := first.AddDate(0, 1, -1).Day()
+			next := first.AddDate(0, 0, min(r.Day, last)-1)
+			if next.After(day) {
+				return next
+			}
+		}
+	}
+
+	for {
+		day = day.AddDate(0, 0, 1)
+		if r.occursOn(day.Weekday()) {
+			return day
+		}
+	}
+}
+
+func (r Recurrence) occursOn(wd time.Weekday) bool {
+	switch r.Kind {
+	case RepeatWeekdays:
+		return wd != time.Saturday && wd != time.Sunday
+	case RepeatWeekly:
+		for _, d := range r.Weekdays {
+			if d == wd {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// NextInstance returns the task that replaces t once it is completed, or
+// false if t doesn't repeat. The new instance gets a fresh ID and an
+// unticked checklist, and stays hidden until its next occurrence, when it
+// also falls due if t had a due date.
+func (t Todo) NextInstance(completed time.Time) (Todo, bool) {
+	if t.Repeat == "" {
+		return Todo{}, false
+	}
+	r, err := ParseRecurrence(t.Repeat)
+	if err != nil {
+		return Todo{}, false
+	}
+
+	next := r.Next(completed)
+	todo := t
+	todo.ID = GenerateID()
+	todo.Created = completed
+	todo.Scheduled = &next
+	todo.Tags = append([]string(nil), t.Tags...)
+	todo.Steps = resetSteps(t.Steps)
+	todo.Pomodoros = nil
+	todo.TimeEntries = nil
+	if t.Due != nil {
+		due := next
+		todo.Due = &due
+	}
+	return todo, true
+}
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurrenceNext(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	// Completed on a Friday evening
+	fri := time.Date(2026, 10, 16, 18, 45, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule      string
+		completed time.Time
+		want      time.Time
+	}{
+		{"daily", fri, day(2026, 10, 17)},
+		{"weekdays", fri, day(2026, 10, 19)},
+		{"weekdays", day(2026, 10, 13), day(2026, 10, 14)},
+		{"weekly:fri", fri, day(2026, 10, 23)},
+		{"weekly:mon,thu", fri, day(2026, 10, 19)},
+		{"weekly:mon,thu", day(2026, 10, 19), day(2026, 10, 22)},
+		{"monthly:15", fri, day(2026, 11, 15)},
+		{"monthly:20", fri, day(2026, 10, 20)},
+		{"monthly:31", day(2026, 11, 2), day(2026, 11, 30)},
+		{"monthly:31", day(2027, 1, 31), day(2027, 2, 28)},
+		{"after:3d", fri, day(2026, 10, 19)},
+		{"after:2w", fri, day(2026, 10, 30)},
+		{"Weekly:Mon", fri, day(2026, 10, 19)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) error: %v", tt.rule, err)
+			}
+			if got := r.Next(tt.completed); !got.Equal(tt.want) {
+				t.Errorf("%s.Next(%s) = %s, want %s", tt.rule, tt.completed.Format("Mon Jan 2"), got.Format("Mon Jan 2"), tt.want.Format("Mon Jan 2"))
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceErrors(t *testing.T) {
+	for _, rule := range []string{"", "hourly", "daily:2", "weekly", "weekly:someday", "monthly", "monthly:0", "monthly:32", "after", "after:0d", "after:3x"} {
+		if _, err := ParseRecurrence(rule); err == nil {
+			t.Errorf("ParseRecurrence(%q) expected error", rule)
+		}
+	}
+}
+
+func TestCompleteRepeatingTask(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 45, 0, 0, time.UTC)
+	due := StartOfDay(now)
+
+	d := NewData()
+	d.Items = []Todo{
+		{ID: "a", Text: "first"},
+		{ID: "b", Text: "rotate notes", Context: "/src/api", Repeat: "weekly:mon", Due: &due, Tags: []string{"ops"},
+			Steps: []Step{{Text: "export", Done: true}, {Text: "upload"}}},
+		{ID: "c", Text: "last"},
+	}
+
+	next := d.Complete(1, now)
+	if next == nil {
+		t.Fatal("expected a next instance")
+	}
+	if next.ID == "b" || next.Text != "rotate notes" || next.Context != "/src/api" {
+		t.Errorf("next instance = %+v", *next)
+	}
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	if next.Scheduled == nil || !next.Scheduled.Equal(monday) || next.Due == nil || !next.Due.Equal(monday) {
+		t.Errorf("next instance should be scheduled and due %s, got %v / %v", monday, next.Scheduled, next.Due)
+	}
+	if len(next.Steps) != 2 || next.Steps[0].Done {
+		t.Errorf("next instance should start with an unticked checklist, got %+v", next.Steps)
+	}
+	if len(d.Items) != 3 || d.Items[1].ID != next.ID {
+		t.Errorf("next instance should take the completed task's place, items = %+v", d.Items)
+	}
+	if len(d.Archive) != 1 || d.Archive[0].ID != "b" || !d.Archive[0].Steps[0].Done || d.Stats.TotalCompleted != 1 {
+		t.Errorf("completed instance not archived: %+v", d.Archive)
+	}
+
+	if d.Complete(0, now) != nil || len(d.Items) != 2 {
+		t.Errorf("one-off task should be removed, items = %+v", d.Items)
+	}
+}
+
+func TestReopenRepeatingTask(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 45, 0, 0, time.UTC)
+
+	d := NewData()
+	d.Items = []Todo{
+		{ID: "a", Text: "first", Position: 0},
+		{ID: "b", Text: "rotate notes", Repeat: "weekly:mon", Position: 1},
+	}
+	next := d.Complete(1, now)
+	if d.Archive[0].Repeat != "weekly:mon" || d.Archive[0].Next != next.ID {
+		t.Fatalf("archived = %+v, want the rule and the next instance", d.Archive[0])
+	}
+
+	archived := d.Archive[0]
+	d.Archive = nil
+	d.Reopen(archived)
+	if len(d.Items) != 2 || d.Items[0].ID != "b" || d.Items[0].Repeat != "weekly:mon" || d.Items[1].ID != "a" {
+		t.Errorf("after reopening, items = %+v; want b, still repeating, then a", d.Items)
+	}
+	if d.Items[0].Position != 0 || d.Items[1].Position != 1 {
+		t.Errorf("positions = %d, %d", d.Items[0].Position, d.Items[1].Position)
+	}
+}
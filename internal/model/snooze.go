@@ -0,0 +1,44 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// IsSnoozed reports whether the task is hidden until a later time
+func (t Todo) IsSnoozed(now time.Time) bool {
+	return t.SnoozedUntil != nil && t.SnoozedUntil.After(now)
+}
+
+// WakeSnoozed clears expired snoozes and moves those tasks to the top of
+// the list, keeping their relative order. It reports whether anything woke.
+func (d *Data) WakeSnoozed(now time.Time) bool {
+	var woke, rest []Todo
+	for _, item := range d.Items {
+		if item.SnoozedUntil != nil && !item.SnoozedUntil.After(now) {
+			item.SnoozedUntil = nil
+			woke = append(woke, item)
+		} else {
+			rest = append(rest, item)
+		}
+	}
+	if len(woke) == 0 {
+		return false
+	}
+
+	d.Items = append(woke, rest...)
+	for i := range d.Items {
+		d.Items[i].Position = i
+	}
+	return true
+}
+
+// ParseWhen resolves a snooze time relative to now. Go-style durations
+// ("45m", "2h", "1h30m") count from now; anything else is a ParseDate day
+// and wakes at the start of that day.
+func ParseWhen(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(strings.TrimSpace(s)); err == nil && d > 0 {
+		return now.Add(d), nil
+	}
+	return ParseDate(s, now)
+}
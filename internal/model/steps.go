@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Step is one item in a task's checklist
+type Step struct {
+	Text string `json:"text"`
+	Done bool   `json:"done,omitempty"`
+}
+
+// StepProgress returns how many of the task's steps are done, and how many
+// there are
+func (t Todo) StepProgress() (done, total int) {
+	for _, s := range t.Steps {
+		if s.Done {
+			done++
+		}
+	}
+	return done, len(t.Steps)
+}
+
+// HasOpenSteps reports whether any of the task's steps are unfinished
+func (t Todo) HasOpenSteps() bool {
+	done, total := t.StepProgress()
+	return done < total
+}
+
+// AddStep appends an unfinished step to the checklist
+func (t *Todo) AddStep(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("step text is empty")
+	}
+	t.Steps = append(t.Steps, Step{Text: text})
+	return nil
+}
+
+// ToggleStep flips the done flag of step n (0-based)
+func (t *Todo) ToggleStep(n int) error {
+	if n < 0 || n >= len(t.Steps) {
+		return fmt.Errorf("no step %d (%d steps)", n+1, len(t.Steps))
+	}
+	t.Steps[n].Done = !t.Steps[n].Done
+	return nil
+}
+
+// StepSummary returns checklist progress such as "2/5", or "" without steps
+func StepSummary(steps []Step) string {
+	if len(steps) == 0 {
+		return ""
+	}
+	done, total := Todo{Steps: steps}.StepProgress()
+	return fmt.Sprintf("%d/%d", done, total)
+}
+
+// resetSteps returns a copy of steps with every step unfinished
+func resetSteps(steps []Step) []Step {
+	if steps == nil {
+		return nil
+	}
+	out := make([]Step, len(steps))
+	for i, s := range steps {
+		out[i] = Step{Text: s.Text}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// NormalizeTag lowercases a tag and strips a leading '#'
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// AddTags appends normalized tags that aren't already present
+func AddTags(tags []string, add ...string) []string {
+	for _, tag := range add {
+		tag = NormalizeTag(tag)
+		if tag == "" || HasTag(tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// RemoveTags returns tags without the given ones
+func RemoveTags(tags []string, remove ...string) []string {
+	var kept []string
+	for _, t := range tags {
+		if !HasTag(remove, t) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// HasTag reports whether tags contains tag
+func HasTag(tags []string, tag string) bool {
+	tag = NormalizeTag(tag)
+	for _, t := range tags {
+		if NormalizeTag(t) == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AllTags returns every tag used by active tasks, sorted
+func (d *Data) AllTags() []string {
+	var tags []string
+	for _, item := range d.Items {
+		tags = AddTags(tags, item.Tags...)
+	}
+	sort.Strings(tags)
+	return tags
+}
@@ -1,7 +1,10 @@
 package model
 
 import (
+	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -37,26 +40,135 @@ func (p Priority) Icon() string {
 	}
 }
 
+// ParsePriority converts a user-supplied priority name into a Priority.
+// It accepts full names ("high") and single-letter shorthands ("h").
+func ParsePriority(s string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "high", "h":
+		return PriorityHigh, nil
+	case "medium", "med", "m":
+		return PriorityMedium, nil
+	case "low", "l":
+		return PriorityLow, nil
+	}
+	return PriorityMedium, fmt.Errorf("unknown priority %q (use high, medium, or low)", s)
+}
+
 // Todo represents an active task in the list
 type Todo struct {
-	ID          string    `json:"id"`
-	Text        string    `json:"text"`
-	Description string    `json:"description,omitempty"`
-	Priority    Priority  `json:"priority"`
-	Created     time.Time `json:"created"`
-	Position    int       `json:"position"`
-	Context     string    `json:"context,omitempty"` // Working directory where task was created
+	ID           string      `json:"id"`
+	Text         string      `json:"text"`
+	Description  string      `json:"description,omitempty"`
+	Priority     Priority    `json:"priority"`
+	Created      time.Time   `json:"created"`
+	Position     int         `json:"position"`
+	Context      string      `json:"context,omitempty"`    // Working directory where task was created
+	Repo         string      `json:"repo,omitempty"`       // Git repository the context is in, see Anchor
+	RepoPath     string      `json:"repo_path,omitempty"`  // Context within Repo, slash-separated
+	Branch       string      `json:"branch,omitempty"`     // Only shown while this git branch is checked out
+	BranchTip    string      `json:"branch_tip,omitempty"` // Commit Branch was at when the task was added
+	Due          *time.Time  `json:"due,omitempty"`        // Day the task should be done by
+	Scheduled    *time.Time  `json:"scheduled,omitempty"`  // Task stays hidden from the active list until this time
+	Tags         []string    `json:"tags,omitempty"`
+	Repeat       string      `json:"repeat,omitempty"`        // Recurrence rule, see ParseRecurrence
+	SnoozedUntil *time.Time  `json:"snoozed_until,omitempty"` // Hidden from the active list until this time
+	Steps        []Step      `json:"steps,omitempty"`         // Ordered checklist
+	BlockedBy    []string    `json:"blocked_by,omitempty"`    // IDs of tasks that must be done first
+	Pomodoros    []time.Time `json:"pomodoros,omitempty"`     // End times of completed work sessions
+	TimeEntries  []TimeEntry `json:"time_entries,omitempty"`  // Time tracked on the task, oldest first
+	Estimate     string      `json:"estimate,omitempty"`      // Expected effort, see ParseEstimate
+	Edited       Edits       `json:"edited,omitempty"`        // When each field was last changed, see Data.StampEdits
 }
 
 // ArchivedTodo represents a completed task
 type ArchivedTodo struct {
-	ID          string    `json:"id"`
-	Text        string    `json:"text"`
-	Description string    `json:"description,omitempty"`
-	Priority    Priority  `json:"priority"`
-	Created     time.Time `json:"created"`
-	Completed   time.Time `json:"completed"`
-	Context     string    `json:"context,omitempty"` // Working directory where task was created
+	ID          string      `json:"id"`
+	Text        string      `json:"text"`
+	Description string      `json:"description,omitempty"`
+	Priority    Priority    `json:"priority"`
+	Created     time.Time   `json:"created"`
+	Completed   time.Time   `json:"completed"`
+	Context     string      `json:"context,omitempty"` // Working directory where task was created
+	Repo        string      `json:"repo,omitempty"`
+	RepoPath    string      `json:"repo_path,omitempty"`
+	Branch      string      `json:"branch,omitempty"`
+	BranchTip   string      `json:"branch_tip,omitempty"`
+	Due         *time.Time  `json:"due,omitempty"`
+	Scheduled   *time.Time  `json:"scheduled,omitempty"`
+	Tags        []string    `json:"tags,omitempty"`
+	Repeat      string      `json:"repeat,omitempty"`
+	Next        string      `json:"next,omitempty"` // ID of the instance a repeating task was replaced by
+	Steps       []Step      `json:"steps,omitempty"`
+	Pomodoros   []time.Time `json:"pomodoros,omitempty"`
+	TimeEntries []TimeEntry `json:"time_entries,omitempty"`
+	Estimate    string      `json:"estimate,omitempty"`
+	Edited      Edits       `json:"edited,omitempty"`
+}
+
+// Archive converts an active task into its archived form
+func (t Todo) Archive(completed time.Time) ArchivedTodo {
+	return ArchivedTodo{
+		ID:          t.ID,
+		Text:        t.Text,
+		Description: t.Description,
+		Priority:    t.Priority,
+		Created:     t.Created,
+		Completed:   completed,
+		Context:     t.Context,
+		Repo:        t.Repo,
+		RepoPath:    t.RepoPath,
+		Branch:      t.Branch,
+		BranchTip:   t.BranchTip,
+		Due:         t.Due,
+		Scheduled:   t.Scheduled,
+		Tags:        t.Tags,
+		Repeat:      t.Repeat,
+		Steps:       t.Steps,
+		Pomodoros:   t.Pomodoros,
+		TimeEntries: t.TimeEntries,
+		Estimate:    t.Estimate,
+		Edited:      t.Edited,
+	}
+}
+
+// Restore converts an archived task back into an active one
+func (a ArchivedTodo) Restore() Todo {
+	return Todo{
+		ID:          a.ID,
+		Text:        a.Text,
+		Description: a.Description,
+		Priority:    a.Priority,
+		Created:     a.Created,
+		Context:     a.Context,
+		Repo:        a.Repo,
+		RepoPath:    a.RepoPath,
+		Branch:      a.Branch,
+		BranchTip:   a.BranchTip,
+		Due:         a.Due,
+		Scheduled:   a.Scheduled,
+		Tags:        a.Tags,
+		Repeat:      a.Repeat,
+		Steps:       a.Steps,
+		Pomodoros:   a.Pomodoros,
+		TimeEntries: a.TimeEntries,
+		Estimate:    a.Estimate,
+		Edited:      a.Edited,
+	}
+}
+
+// IsOverdue reports whether the task's due day is before today
+func (t Todo) IsOverdue(now time.Time) bool {
+	return t.Due != nil && t.Due.Before(StartOfDay(now))
+}
+
+// IsDueToday reports whether the task is due on the same day as now
+func (t Todo) IsDueToday(now time.Time) bool {
+	return t.Due != nil && StartOfDay(*t.Due).Equal(StartOfDay(now))
+}
+
+// IsScheduledLater reports whether the task is hidden until a future time
+func (t Todo) IsScheduledLater(now time.Time) bool {
+	return t.Scheduled != nil && t.Scheduled.After(now)
 }
 
 // Stats tracks completion metrics
@@ -83,6 +195,76 @@ func NewData() *Data {
 	}
 }
 
+// Clone returns a deep copy of d, sharing nothing with it
+func (d *Data) Clone() *Data {
+	clone := &Data{
+		Version: d.Version,
+		Items:   make([]Todo, len(d.Items)),
+		Archive: make([]ArchivedTodo, len(d.Archive)),
+		Stats:   d.Stats,
+	}
+	for i, item := range d.Items {
+		clone.Items[i] = item.clone()
+	}
+	for i, item := range d.Archive {
+		clone.Archive[i] = item.clone()
+	}
+	return clone
+}
+
+// clone returns a copy of t sharing no slices or pointers with it
+func (t Todo) clone() Todo {
+	t.Due = cloneTime(t.Due)
+	t.Scheduled = cloneTime(t.Scheduled)
+	t.SnoozedUntil = cloneTime(t.SnoozedUntil)
+	t.Tags = slices.Clone(t.Tags)
+	t.Steps = slices.Clone(t.Steps)
+	t.BlockedBy = slices.Clone(t.BlockedBy)
+	t.Pomodoros = slices.Clone(t.Pomodoros)
+	t.TimeEntries = cloneEntries(t.TimeEntries)
+	t.Edited = maps.Clone(t.Edited)
+	return t
+}
+
+// clone returns a copy of a sharing no slices or pointers with it
+func (a ArchivedTodo) clone() ArchivedTodo {
+	a.Due = cloneTime(a.Due)
+	a.Scheduled = cloneTime(a.Scheduled)
+	a.Tags = slices.Clone(a.Tags)
+	a.Steps = slices.Clone(a.Steps)
+	a.Pomodoros = slices.Clone(a.Pomodoros)
+	a.TimeEntries = cloneEntries(a.TimeEntries)
+	a.Edited = maps.Clone(a.Edited)
+	return a
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func cloneEntries(entries []TimeEntry) []TimeEntry {
+	if entries == nil {
+		return nil
+	}
+	clone := make([]TimeEntry, len(entries))
+	for i, entry := range entries {
+		clone[i] = TimeEntry{Start: entry.Start, End: cloneTime(entry.End)}
+	}
+	return clone
+}
+
+// Plural returns word, with an "s" unless n is 1
+func Plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
 // GenerateID creates a unique ID based on timestamp
 func GenerateID() string {
 	return time.Now().Format("20060102150405.000000000")
@@ -145,24 +327,101 @@ func GetContextDisplay(taskContext, cwd string) string {
 	return rel
 }
 
-// FilterByContext returns items that are relevant to the given context
-func (d *Data) FilterByContext(cwd string) []Todo {
+// Complete archives the task at index i, stopping its timer. A repeating
+// task is replaced in place by its next instance, which is returned.
+func (d *Data) Complete(i int, now time.Time) *Todo {
+	d.Items[i].stopTracking(now)
+	item := d.Items[i]
+	archived := item.Archive(now)
+	d.Stats.TotalCompleted++
+
+	if next, ok := item.NextInstance(now); ok {
+		archived.Next = next.ID
+		d.Archive = append(d.Archive, archived)
+		d.Items[i] = next
+		return &d.Items[i]
+	}
+	d.Archive = append(d.Archive, archived)
+	d.Items = append(d.Items[:i], d.Items[i+1:]...)
+	return nil
+}
+
+// Reopen makes a task taken out of the archive active again, at the top of
+// the list. The instance a repeating task was replaced by on completion is
+// dropped, so the task isn't listed twice.
+func (d *Data) Reopen(a ArchivedTodo) {
+	if a.Next != "" {
+		for i, item := range d.Items {
+			if item.ID == a.Next {
+				d.Items = append(d.Items[:i], d.Items[i+1:]...)
+				break
+			}
+		}
+	}
+
+	for i := range d.Items {
+		d.Items[i].Position++
+	}
+	todo := a.Restore()
+	todo.Position = 0
+	d.Items = append([]Todo{todo}, d.Items...)
+}
+
+// FilterByContext returns items that are relevant to the given context.
+// co is the git checkout cwd is in, which tasks anchored to its
+// repository are matched against.
+func (d *Data) FilterByContext(cwd string, co Checkout) []Todo {
 	var filtered []Todo
 	for _, item := range d.Items {
-		if IsContextRelevant(item.Context, cwd) {
+		if IsContextRelevant(item.ContextIn(co), cwd) {
 			filtered = append(filtered, item)
 		}
 	}
 	return filtered
 }
 
-// FilterArchiveByContext returns archived items relevant to the given context
-func (d *Data) FilterArchiveByContext(cwd string) []ArchivedTodo {
+// FilterArchiveByContext returns archived items relevant to the given
+// context, as FilterByContext
+func (d *Data) FilterArchiveByContext(cwd string, co Checkout) []ArchivedTodo {
 	var filtered []ArchivedTodo
 	for _, item := range d.Archive {
-		if IsContextRelevant(item.Context, cwd) {
+		if IsContextRelevant(item.ContextIn(co), cwd) {
 			filtered = append(filtered, item)
 		}
 	}
 	return filtered
 }
+
+// FindTodo returns the index in Items of the task whose ID is ref, or whose
+// ID uniquely starts or ends with ref
+func (d *Data) FindTodo(ref string) (int, error) {
+	if ref == "" {
+		return -1, fmt.Errorf("no task ID given")
+	}
+	for i, item := range d.Items {
+		if item.ID == ref {
+			return i, nil
+		}
+	}
+
+	for _, match := range []func(id string) bool{
+		func(id string) bool { return strings.HasPrefix(id, ref) },
+		func(id string) bool { return strings.HasSuffix(id, ref) },
+	} {
+		found := -1
+		for i, item := range d.Items {
+			if !match(item.ID) {
+				continue
+			}
+			if found >= 0 {
+				return -1, fmt.Errorf("task ID %q is ambiguous", ref)
+			}
+			found = i
+		}
+		if found >= 0 {
+			return found, nil
+		}
+	}
+
+	return -1, fmt.Errorf("no task with ID %q", ref)
+}
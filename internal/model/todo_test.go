@@ -0,0 +1,42 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClone(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	end := now.Add(time.Hour)
+	d := NewData()
+	d.Items = []Todo{{
+		ID: "a", Text: "write", Due: &now, Scheduled: &now, SnoozedUntil: &now,
+		Tags: []string{"docs"}, Steps: []Step{{Text: "outline"}}, BlockedBy: []string{"b"},
+		Pomodoros: []time.Time{now}, TimeEntries: []TimeEntry{{Start: now, End: &end}},
+	}}
+	d.Archive = []ArchivedTodo{{ID: "b", Due: &now, Tags: []string{"ops"}, TimeEntries: []TimeEntry{{Start: now, End: &end}}}}
+	d.Stats.TotalCompleted = 3
+
+	clone := d.Clone()
+	if !reflect.DeepEqual(clone, d) {
+		t.Fatalf("Clone() = %+v, want %+v", clone, d)
+	}
+
+	item := &clone.Items[0]
+	*item.Due = now.AddDate(0, 0, 1)
+	item.Tags[0] = "changed"
+	item.Steps[0].Done = true
+	item.BlockedBy[0] = "c"
+	*item.TimeEntries[0].End = now
+	clone.Archive[0].Tags[0] = "changed"
+	*clone.Archive[0].TimeEntries[0].End = now
+	clone.Stats.TotalCompleted++
+
+	orig := d.Items[0]
+	if !orig.Due.Equal(now) || orig.Tags[0] != "docs" || orig.Steps[0].Done || orig.BlockedBy[0] != "b" ||
+		!orig.TimeEntries[0].End.Equal(end) || d.Archive[0].Tags[0] != "ops" || !d.Archive[0].TimeEntries[0].End.Equal(end) ||
+		d.Stats.TotalCompleted != 3 {
+		t.Errorf("changing the clone changed the original: %+v, %+v", orig, d.Archive[0])
+	}
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeEntry is a stretch of time spent on a task. End is nil while the
+// entry is running.
+type TimeEntry struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// Duration returns how long the entry ran, counting a running entry up to now
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.End != nil {
+		end = *e.End
+	}
+	return max(end.Sub(e.Start), 0)
+}
+
+// TrackedSince returns the time logged in entries at or after since. An
+// entry that started earlier counts from since.
+func TrackedSince(entries []TimeEntry, since, now time.Time) time.Duration {
+	var total time.Duration
+	for _, e := range entries {
+		if e.Start.Before(since) {
+			e.Start = since
+		}
+		total += e.Duration(now)
+	}
+	return total
+}
+
+// TrackedTime returns the total time logged on the task
+func (t Todo) TrackedTime(now time.Time) time.Duration {
+	return TrackedSince(t.TimeEntries, time.Time{}, now)
+}
+
+// TrackedTime returns the total time logged on the task before it was completed
+func (a ArchivedTodo) TrackedTime() time.Duration {
+	return TrackedSince(a.TimeEntries, time.Time{}, a.Completed)
+}
+
+// IsTracking reports whether the task has a running time entry
+func (t Todo) IsTracking() bool {
+	n := len(t.TimeEntries)
+	return n > 0 && t.TimeEntries[n-1].End == nil
+}
+
+// Tracking returns the index of the task whose time is being tracked
+func (d *Data) Tracking() (int, bool) {
+	for i, item := range d.Items {
+		if item.IsTracking() {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// StartTracking starts a time entry on the task at index i. Only one entry
+// runs at a time, so one running on another task is stopped first; that
+// task's index is returned.
+func (d *Data) StartTracking(i int, now time.Time) (stopped int, ok bool, err error) {
+	if d.Items[i].IsTracking() {
+		return 0, false, fmt.Errorf("already tracking %s", d.Items[i].Text)
+	}
+	stopped, ok = d.StopTracking(now)
+	d.Items[i].TimeEntries = append(d.Items[i].TimeEntries, TimeEntry{Start: now})
+	return stopped, ok, nil
+}
+
+// StopTracking ends the running time entry, returning the index of its
+// task, or false if nothing was being tracked
+func (d *Data) StopTracking(now time.Time) (int, bool) {
+	i, ok := d.Tracking()
+	if ok {
+		d.Items[i].stopTracking(now)
+	}
+	return i, ok
+}
+
+// stopTracking ends the task's running time entry, if it has one
+func (t *Todo) stopTracking(now time.Time) {
+	if t.IsTracking() {
+		end := now
+		t.TimeEntries[len(t.TimeEntries)-1].End = &end
+	}
+}
+
+// FormatTracked renders a tracked duration as "2h 05m" or "45m"
+func FormatTracked(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracking(t *testing.T) {
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	d := NewData()
+	d.Items = []Todo{{ID: "a", Text: "first"}, {ID: "b", Text: "second"}}
+
+	if _, ok, err := d.StartTracking(0, start); ok || err != nil {
+		t.Fatalf("StartTracking = %v, %v; nothing should have been stopped", ok, err)
+	}
+	if _, _, err := d.StartTracking(0, start.Add(time.Minute)); err == nil {
+		t.Error("starting a running task again should fail")
+	}
+
+	// Switching tasks stops the running entry
+	stopped, ok, err := d.StartTracking(1, start.Add(30*time.Minute))
+	if err != nil || !ok || stopped != 0 {
+		t.Fatalf("StartTracking = %d, %v, %v; want task 0 stopped", stopped, ok, err)
+	}
+	if d.Items[0].IsTracking() || d.Items[0].TrackedTime(start.Add(time.Hour)) != 30*time.Minute {
+		t.Errorf("first task entries = %+v", d.Items[0].TimeEntries)
+	}
+	if i, ok := d.Tracking(); !ok || i != 1 {
+		t.Errorf("Tracking() = %d, %v; want 1", i, ok)
+	}
+	if got := d.Items[1].TrackedTime(start.Add(time.Hour)); got != 30*time.Minute {
+		t.Errorf("running entry counts up to now: got %s", got)
+	}
+
+	// Completing stops the clock, and the archive keeps the total
+	d.Complete(1, start.Add(45*time.Minute))
+	if _, ok := d.Tracking(); ok {
+		t.Error("completing a task should stop its tracking")
+	}
+	if got := d.Archive[0].TrackedTime(); got != 15*time.Minute {
+		t.Errorf("archived tracked time = %s, want 15m", got)
+	}
+
+	if got := TrackedSince(d.Items[0].TimeEntries, start.Add(20*time.Minute), start.Add(time.Hour)); got != 10*time.Minute {
+		t.Errorf("TrackedSince clipped = %s, want 10m", got)
+	}
+	if _, ok := d.StopTracking(start.Add(time.Hour)); ok {
+		t.Error("StopTracking with nothing running should report false")
+	}
+}
+
+func TestFormatTracked(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                                  "0m",
+		45*time.Minute + 20*time.Second:    "45m",
+		2*time.Hour + 5*time.Minute:        "2h 05m",
+		26*time.Hour + 59*time.Minute + 45: "26h 59m",
+	} {
+		if got := FormatTracked(d); got != want {
+			t.Errorf("FormatTracked(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"upnext/internal/model"
+)
+
+// Completed tasks past the retention period live in cold storage: one
+// segment file per month of completion under archive/, with one JSON
+// object per line. Segments are only ever appended to, so saving never
+// rewrites history. Deleting a task appends a tombstone for it.
+//
+// Cold storage used to be one archive-YYYY.json file per year, holding
+// {"archive": [...]}. Those are moved into segments the first time cold
+// storage is read.
+
+// segmentEntry is a line in a segment file
+type segmentEntry struct {
+	model.ArchivedTodo
+	Deleted bool `json:"deleted,omitempty"` // Tombstone for a task removed from cold storage
+}
+
+// MarshalJSON writes a tombstone with only what's needed to apply it: the
+// ID, and the completion time that picks its segment
+func (e segmentEntry) MarshalJSON() ([]byte, error) {
+	if !e.Deleted {
+		return json.Marshal(e.ArchivedTodo)
+	}
+	return json.Marshal(struct {
+		ID        string    `json:"id"`
+		Completed time.Time `json:"completed"`
+		Deleted   bool      `json:"deleted"`
+	}{e.ID, e.Completed, true})
+}
+
+// SegmentPath returns the segment file for tasks completed in the month of t
+func (s *JSONStore) SegmentPath(t time.Time) string {
+	return filepath.Join(filepath.Dir(s.path), "archive", t.Format("2006-01")+".jsonl")
+}
+
+// SaveCold appends completed tasks to the segments for the months they
+// were completed in
+func (s *JSONStore) SaveCold(items []model.ArchivedTodo) error {
+	entries := make([]segmentEntry, len(items))
+	for i, item := range items {
+		entries[i] = segmentEntry{ArchivedTodo: item}
+	}
+	return s.appendSegments(entries)
+}
+
+// DeleteCold removes completed tasks from cold storage
+func (s *JSONStore) DeleteCold(items []model.ArchivedTodo) error {
+	entries := make([]segmentEntry, len(items))
+	for i, item := range items {
+		entries[i] = segmentEntry{ArchivedTodo: item, Deleted: true}
+	}
+	return s.appendSegments(entries)
+}
+
+// LoadCold reads every segment, oldest task first. A task saved more than
+// once is returned once, as last saved. Tasks not yet anchored to the
+// store's repository that belong to it are anchored, and saved again so.
+func (s *JSONStore) LoadCold() ([]model.ArchivedTodo, error) {
+	if err := s.migrateYearly(); err != nil {
+		return nil, fmt.Errorf("failed to move yearly archive files into archive/: %w", err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(filepath.Dir(s.path), "archive", "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var items []model.ArchivedTodo
+	for _, path := range paths {
+		segment, err := readSegment(path)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, segment...)
+	}
+
+	if s.repo != nil {
+		var anchored []model.ArchivedTodo
+		for i := range items {
+			if items[i].Anchor(s.repo.ID, s.repo.Root) {
+				anchored = append(anchored, items[i])
+			}
+		}
+		if err := s.SaveCold(anchored); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// migrateYearly moves the tasks in yearly archive-YYYY.json files into
+// segments, removing each file once its tasks are saved
+func (s *JSONStore) migrateYearly() error {
+	paths, err := filepath.Glob(filepath.Join(filepath.Dir(s.path), "archive-*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var file struct {
+			Archive []model.ArchivedTodo `json:"archive"`
+		}
+		if err := json.Unmarshal(raw, &file); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := s.SaveCold(file.Archive); err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendSegments appends entries to the segments their completion times
+// fall in
+func (s *JSONStore) appendSegments(entries []segmentEntry) error {
+	bySegment := map[string][]segmentEntry{}
+	for _, entry := range entries {
+		path := s.SegmentPath(entry.Completed)
+		bySegment[path] = append(bySegment[path], entry)
+	}
+
+	for path, entries := range bySegment {
+		var buf bytes.Buffer
+		for _, entry := range entries {
+			line, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			buf.Write(line)
+			buf.WriteByte('\n')
+		}
+		if err := appendFile(path, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendFile adds lines to the end of the file at path, creating it if
+// needed. A last line cut short by an earlier crash is ended first, so
+// the new lines stay whole.
+func appendFile(path string, lines []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			lines = append([]byte{'\n'}, lines...)
+		}
+	}
+
+	if _, err := f.Write(lines); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readSegment reads the tasks in a segment, applying tombstones. Lines
+// that don't parse, like one cut short by a crash, are skipped.
+func readSegment(path string) ([]model.ArchivedTodo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var order []string
+	latest := map[string]model.ArchivedTodo{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry segmentEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.ID == "" {
+			continue
+		}
+		if entry.Deleted {
+			delete(latest, entry.ID)
+			continue
+		}
+		if _, ok := latest[entry.ID]; !ok {
+			order = append(order, entry.ID)
+		}
+		latest[entry.ID] = entry.ArchivedTodo
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	items := make([]model.ArchivedTodo, 0, len(latest))
+	for _, id := range order {
+		if item, ok := latest[id]; ok {
+			items = append(items, item)
+			delete(latest, id)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Completed.Before(items[j].Completed)
+	})
+	return items, nil
+}
+
+// retire moves completed tasks older than the retention period out of data
+// and into cold storage, returning the ones moved
+func (s *JSONStore) retire(data *model.Data, now time.Time) ([]model.ArchivedTodo, error) {
+	if s.retention <= 0 {
+		return nil, nil
+	}
+	cutoff := now.Add(-s.retention)
+	old := data.TakeArchived(func(a model.ArchivedTodo) bool {
+		return a.Completed.Before(cutoff)
+	})
+	if len(old) == 0 {
+		return nil, nil
+	}
+	if err := s.SaveCold(old); err != nil {
+		// Keep them in the main file rather than lose them
+		data.Archive = append(old, data.Archive...)
+		return nil, fmt.Errorf("failed to move old completed tasks to cold storage: %w", err)
+	}
+	return old, nil
+}
+
+// unretire undoes retire after the main file couldn't be written, so the
+// tasks stay where the main file last had them. If the tombstones can't be
+// written either, the copies left in cold storage are harmless: the main
+// file's copy wins when the two are merged.
+func (s *JSONStore) unretire(data *model.Data, retired []model.ArchivedTodo) {
+	if len(retired) == 0 {
+		return
+	}
+	data.Archive = append(retired, data.Archive...)
+	_ = s.DeleteCold(retired)
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"upnext/internal/model"
+)
+
+func TestColdStorage(t *testing.T) {
+	s := &JSONStore{path: filepath.Join(t.TempDir(), "todos.json"), retention: 30 * 24 * time.Hour}
+	now := time.Now()
+	old := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	data := model.NewData()
+	data.Archive = []model.ArchivedTodo{
+		{ID: "a", Text: "old", Completed: old},
+		{ID: "b", Text: "older", Completed: old.AddDate(0, -1, 0)},
+		{ID: "c", Text: "recent", Completed: now},
+	}
+	if err := s.Save(data); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Archive) != 1 || loaded.Archive[0].ID != "c" {
+		t.Errorf("main file archive = %+v, want only the recent task", loaded.Archive)
+	}
+	for _, month := range []time.Time{old, old.AddDate(0, -1, 0)} {
+		if _, err := os.Stat(s.SegmentPath(month)); err != nil {
+			t.Errorf("no segment for %s: %v", month.Format("2006-01"), err)
+		}
+	}
+
+	if err := s.DeleteCold([]model.ArchivedTodo{{ID: "a", Completed: old}}); err != nil {
+		t.Fatal(err)
+	}
+	cold, err := s.LoadCold()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cold) != 1 || cold[0].ID != "b" {
+		t.Errorf("LoadCold() = %+v, want b, with a deleted", cold)
+	}
+
+	// Anchoring to a repository covers cold storage too
+	if err := s.SaveCold([]model.ArchivedTodo{{ID: "e", Context: "/src/api/cmd", Completed: old}}); err != nil {
+		t.Fatal(err)
+	}
+	s.repo = &Repo{ID: "github.com/me/api", Root: "/src/api"}
+	if _, err := s.LoadCold(); err != nil {
+		t.Fatal(err)
+	}
+	s.repo = nil
+	if cold, err = s.LoadCold(); err != nil {
+		t.Fatal(err)
+	}
+	if e := cold[len(cold)-1]; e.ID != "e" || e.Repo != "github.com/me/api" || e.RepoPath != "cmd" || e.Context != "/src/api/cmd" {
+		t.Errorf("cold task after anchoring = %+v, want it anchored at cmd", e)
+	}
+}
+
+func TestColdStorageYearlyFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := &JSONStore{path: filepath.Join(dir, "todos.json")}
+	yearly := filepath.Join(dir, "archive-2025.json")
+	content := `{"archive": [
+		{"id": "a", "text": "from last year", "created": "2025-03-01T09:00:00Z", "completed": "2025-03-02T09:00:00Z"},
+		{"id": "b", "text": "also", "created": "2025-11-01T09:00:00Z", "completed": "2025-11-02T09:00:00Z"}
+	]}`
+	if err := os.WriteFile(yearly, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		cold, err := s.LoadCold()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cold) != 2 || cold[0].ID != "a" || cold[1].ID != "b" {
+			t.Errorf("LoadCold() #%d = %+v, want a and b", i+1, cold)
+		}
+	}
+	if _, err := os.Stat(yearly); !os.IsNotExist(err) {
+		t.Errorf("the yearly file is still there: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "archive", "2025-11.jsonl")); err != nil {
+		t.Errorf("no segment for 2025-11: %v", err)
+	}
+}
@@ -0,0 +1,291 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"upnext/internal/model"
+)
+
+// Kinds of event in the log
+const (
+	EventAdded       = "added"       // A task was added; Todo holds it
+	EventEdited      = "edited"      // A task changed; Todo holds it, Fields what changed
+	EventCompleted   = "completed"   // A task was completed; Archived holds it
+	EventUncompleted = "uncompleted" // A completed task went back on the list; Todo holds it
+	EventDropped     = "dropped"     // A task was deleted without being completed
+	EventDeleted     = "deleted"     // A completed task was deleted
+	EventBumped      = "bumped"      // A task moved to the top; Order holds the new order
+	EventMoved       = "moved"       // Tasks were reordered; Order holds the new order
+	EventStats       = "stats"       // The completion stats changed; Stats holds by how much
+)
+
+// Event is one change to the data, as recorded in the event log
+type Event struct {
+	Time     time.Time           `json:"time"`
+	Type     string              `json:"type"`
+	ID       string              `json:"id,omitempty"`       // Task the event is about, empty when several moved
+	Fields   []string            `json:"fields,omitempty"`   // JSON names of the fields an edit changed
+	Todo     *model.Todo         `json:"todo,omitempty"`     // Task as added, edited or uncompleted
+	Archived *model.ArchivedTodo `json:"archived,omitempty"` // Task as completed
+	Order    []string            `json:"order,omitempty"`    // IDs of the active tasks after a move
+	Stats    *model.Stats        `json:"stats,omitempty"`    // Change in the stats, so concurrent completions add up
+}
+
+// Diff returns the events that turn before into after. Replaying them
+// with Apply gives the same tasks in the same order, except that positions
+// are renumbered to match the order.
+func Diff(before, after *model.Data, now time.Time) []Event {
+	var events []Event
+	sim := before.Clone()
+	fresh := map[string]bool{} // Tasks added or uncompleted just now
+	emit := func(e Event) {
+		e.Time = now
+		Apply(sim, e)
+		events = append(events, e)
+		if e.Type == EventAdded || e.Type == EventUncompleted {
+			fresh[e.ID] = true
+		}
+	}
+
+	beforeItems := todosByID(before.Items)
+	afterItems := todosByID(after.Items)
+	beforeArchive := archivedByID(before.Archive)
+	afterArchive := archivedByID(after.Archive)
+
+	// Completions come first, so a repeating task's next instance can
+	// be added after
+	for _, item := range after.Archive {
+		if old, ok := beforeArchive[item.ID]; !ok || !sameJSON(old, item) {
+			item := item
+			emit(Event{Type: EventCompleted, ID: item.ID, Archived: &item})
+		}
+	}
+	for _, item := range before.Archive {
+		if _, ok := afterArchive[item.ID]; ok {
+			continue
+		}
+		if todo, ok := afterItems[item.ID]; ok {
+			emit(Event{Type: EventUncompleted, ID: item.ID, Todo: &todo})
+		} else {
+			emit(Event{Type: EventDeleted, ID: item.ID})
+		}
+	}
+
+	for _, item := range before.Items {
+		_, active := afterItems[item.ID]
+		_, completed := afterArchive[item.ID]
+		if !active && !completed {
+			emit(Event{Type: EventDropped, ID: item.ID})
+		}
+	}
+	for _, item := range after.Items {
+		item := item
+		old, ok := beforeItems[item.ID]
+		switch {
+		case !ok && !inTodos(sim.Items, item.ID):
+			emit(Event{Type: EventAdded, ID: item.ID, Todo: &item})
+		case !ok:
+			// Uncompleted above; it may have changed since
+			if current := findTodo(sim.Items, item.ID); !sameTodo(current, item) {
+				emit(Event{Type: EventEdited, ID: item.ID, Fields: changedFields(current, item), Todo: &item})
+			}
+		case !sameTodo(old, item):
+			emit(Event{Type: EventEdited, ID: item.ID, Fields: changedFields(old, item), Todo: &item})
+		}
+	}
+
+	if order := todoIDs(after.Items); !reflect.DeepEqual(todoIDs(sim.Items), order) {
+		id := movedTask(todoIDs(sim.Items), order)
+		if fresh[id] {
+			// Putting a new task in its place isn't a move of its own
+			id = ""
+		}
+		kind := EventMoved
+		if id != "" && order[0] == id {
+			kind = EventBumped
+		}
+		emit(Event{Type: kind, ID: id, Order: order})
+	}
+
+	if before.Stats != after.Stats {
+		delta := model.Stats{
+			TotalCompleted: after.Stats.TotalCompleted - before.Stats.TotalCompleted,
+			StreakDays:     after.Stats.StreakDays - before.Stats.StreakDays,
+		}
+		emit(Event{Type: EventStats, Stats: &delta})
+	}
+	return events
+}
+
+// Apply replays one event onto data
+func Apply(data *model.Data, e Event) {
+	switch e.Type {
+	case EventAdded, EventEdited:
+		if i := indexOfTodo(data.Items, e.ID); i >= 0 {
+			data.Items[i] = *e.Todo
+		} else {
+			data.Items = append(data.Items, *e.Todo)
+		}
+	case EventCompleted:
+		if i := indexOfTodo(data.Items, e.ID); i >= 0 {
+			data.Items = append(data.Items[:i], data.Items[i+1:]...)
+		}
+		if i := indexOfArchived(data.Archive, e.ID); i >= 0 {
+			data.Archive[i] = *e.Archived
+		} else {
+			data.Archive = append(data.Archive, *e.Archived)
+		}
+	case EventUncompleted:
+		if i := indexOfArchived(data.Archive, e.ID); i >= 0 {
+			data.Archive = append(data.Archive[:i], data.Archive[i+1:]...)
+		}
+		data.Items = append([]model.Todo{*e.Todo}, data.Items...)
+	case EventDropped:
+		if i := indexOfTodo(data.Items, e.ID); i >= 0 {
+			data.Items = append(data.Items[:i], data.Items[i+1:]...)
+		}
+	case EventDeleted:
+		if i := indexOfArchived(data.Archive, e.ID); i >= 0 {
+			data.Archive = append(data.Archive[:i], data.Archive[i+1:]...)
+		}
+	case EventBumped, EventMoved:
+		rank := make(map[string]int, len(e.Order))
+		for i, id := range e.Order {
+			rank[id] = i
+		}
+		sort.SliceStable(data.Items, func(i, j int) bool {
+			ri, ok := rank[data.Items[i].ID]
+			if !ok {
+				ri = len(e.Order)
+			}
+			rj, ok := rank[data.Items[j].ID]
+			if !ok {
+				rj = len(e.Order)
+			}
+			return ri < rj
+		})
+		for i := range data.Items {
+			data.Items[i].Position = i
+		}
+	case EventStats:
+		data.Stats.TotalCompleted += e.Stats.TotalCompleted
+		data.Stats.StreakDays += e.Stats.StreakDays
+	}
+}
+
+// movedTask returns the one task whose move turns before into after, or
+// "" when it took more than one
+func movedTask(before, after []string) string {
+	i := 0
+	for i < len(before) && i < len(after) && before[i] == after[i] {
+		i++
+	}
+	if i == len(before) || i == len(after) {
+		return ""
+	}
+	for _, id := range []string{after[i], before[i]} {
+		if reflect.DeepEqual(withoutID(before, id), withoutID(after, id)) {
+			return id
+		}
+	}
+	return ""
+}
+
+// changedFields returns the JSON names of the fields that differ between
+// two versions of a task, position aside
+func changedFields(before, after model.Todo) []string {
+	a, b := model.JSONFields(before), model.JSONFields(after)
+	var fields []string
+	for name, value := range b {
+		if name != "position" && !bytes.Equal(a[name], value) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// sameTodo reports whether two versions of a task match, position aside
+func sameTodo(a, b model.Todo) bool {
+	a.Position, b.Position = 0, 0
+	return sameJSON(a, b)
+}
+
+// sameJSON reports whether a and b encode to the same JSON
+func sameJSON(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+func todosByID(items []model.Todo) map[string]model.Todo {
+	byID := make(map[string]model.Todo, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	return byID
+}
+
+func archivedByID(items []model.ArchivedTodo) map[string]model.ArchivedTodo {
+	byID := make(map[string]model.ArchivedTodo, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	return byID
+}
+
+func todoIDs(items []model.Todo) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func withoutID(ids []string, id string) []string {
+	var rest []string
+	for _, other := range ids {
+		if other != id {
+			rest = append(rest, other)
+		}
+	}
+	return rest
+}
+
+func indexOfTodo(items []model.Todo, id string) int {
+	for i, item := range items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexOfArchived(items []model.ArchivedTodo, id string) int {
+	for i, item := range items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func inTodos(items []model.Todo, id string) bool {
+	return indexOfTodo(items, id) >= 0
+}
+
+func findTodo(items []model.Todo, id string) model.Todo {
+	if i := indexOfTodo(items, id); i >= 0 {
+		return items[i]
+	}
+	return model.Todo{}
+}
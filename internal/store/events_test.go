@@ -0,0 +1,221 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"upnext/internal/model"
+)
+
+func testData() *model.Data {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	d := model.NewData()
+	for _, id := range []string{"a", "b", "c", "d"} {
+		d.Items = append(d.Items, model.Todo{ID: id, Text: "task " + id, Created: created})
+	}
+	d.Archive = append(d.Archive, model.ArchivedTodo{ID: "z", Text: "task z", Created: created, Completed: created.Add(time.Hour)})
+	d.Stats.TotalCompleted = 1
+	return d
+}
+
+// normalized encodes data for comparison, with positions renumbered the
+// way replaying does
+func normalized(t *testing.T, d *model.Data) string {
+	t.Helper()
+	d = d.Clone()
+	if len(d.Archive) == 0 {
+		d.Archive = []model.ArchivedTodo{}
+	}
+	for i := range d.Items {
+		d.Items[i].Position = i
+	}
+	raw, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func TestDiffApply(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		change func(d *model.Data)
+		want   string // Event types and IDs
+	}{
+		{"nothing", func(d *model.Data) {}, ""},
+		{"add", func(d *model.Data) {
+			d.Items = append([]model.Todo{{ID: "e", Text: "task e"}}, d.Items...)
+		}, "added e, moved"},
+		{"edit", func(d *model.Data) {
+			d.Items[1].Text = "renamed"
+			d.Items[1].Tags = []string{"ops"}
+		}, "edited b"},
+		{"complete", func(d *model.Data) { d.Complete(2, now) }, "completed c, stats"},
+		{"complete repeating", func(d *model.Data) {
+			d.Items[0].Repeat = "daily"
+			d.Complete(0, now)
+		}, "completed a, added *, moved, stats"},
+		{"drop", func(d *model.Data) { d.Items = append(d.Items[:1], d.Items[2:]...) }, "dropped b"},
+		{"uncomplete", func(d *model.Data) {
+			todo := d.Archive[0].Restore()
+			todo.Text = "reopened"
+			d.Archive = nil
+			d.Items = append(d.Items, todo)
+		}, "uncompleted z, moved"},
+		{"delete completed", func(d *model.Data) { d.Archive = nil }, "deleted z"},
+		{"bump", func(d *model.Data) { d.MoveTodo(3, 0, false) }, "bumped d"},
+		{"move down", func(d *model.Data) { d.MoveTodo(0, 2, true) }, "moved a"},
+		{"reverse", func(d *model.Data) {
+			d.Items[0], d.Items[1], d.Items[2], d.Items[3] = d.Items[3], d.Items[2], d.Items[1], d.Items[0]
+		}, "moved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := testData()
+			after := before.Clone()
+			tt.change(after)
+
+			events := Diff(before, after, now)
+			var got []string
+			for _, e := range events {
+				label := e.Type
+				if e.ID != "" {
+					id := e.ID
+					if len(id) > 1 {
+						id = "*" // A generated ID
+					}
+					label += " " + id
+				}
+				got = append(got, label)
+			}
+			if strings.Join(got, ", ") != tt.want {
+				t.Errorf("Diff events = %q, want %q", strings.Join(got, ", "), tt.want)
+			}
+
+			replayed := before.Clone()
+			for _, e := range events {
+				Apply(replayed, e)
+			}
+			if normalized(t, replayed) != normalized(t, after) {
+				t.Errorf("replay gave\n%s\nwant\n%s", normalized(t, replayed), normalized(t, after))
+			}
+		})
+	}
+}
+
+func TestDiffEditFields(t *testing.T) {
+	before := testData()
+	after := before.Clone()
+	after.Items[0].Text = "renamed"
+	after.Items[0].Estimate = "30m"
+
+	events := Diff(before, after, time.Now())
+	if len(events) != 1 || strings.Join(events[0].Fields, ",") != "estimate,text" {
+		t.Errorf("Diff = %+v, want one edit of estimate and text", events)
+	}
+}
+
+func TestEventStore(t *testing.T) {
+	dir := t.TempDir()
+	s := NewEventStore(dir)
+	s.snapshotEvery = 3
+
+	data, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		data.Items = append(data.Items, model.Todo{ID: id, Text: "task " + id})
+		if err := s.Save(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "snapshot.json")); err != nil {
+		t.Errorf("no snapshot after %d events: %v", s.snapshotEvery, err)
+	}
+
+	// A second process adds a task and completes another while the first
+	// still holds its data
+	other := NewEventStore(dir)
+	otherData, err := other.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherData.Items = append(otherData.Items, model.Todo{ID: "d", Text: "task d"})
+	otherData.Complete(1, time.Now())
+	if err := other.Save(otherData); err != nil {
+		t.Fatal(err)
+	}
+
+	data.Complete(0, time.Now())
+	if err := s.Save(data); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := NewEventStore(dir).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, item := range loaded.Items {
+		ids = append(ids, item.ID)
+	}
+	if strings.Join(ids, "") != "cd" || len(loaded.Archive) != 2 || loaded.Stats.TotalCompleted != 2 {
+		t.Errorf("loaded items %v, %d completed (%d total); want cd, with a and b completed", ids, len(loaded.Archive), loaded.Stats.TotalCompleted)
+	}
+
+	history, err := s.History("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, e := range history {
+		kinds = append(kinds, e.Type)
+	}
+	if strings.Join(kinds, ",") != "added,completed" {
+		t.Errorf("History(a) = %v, want added then completed", kinds)
+	}
+}
+
+func TestEventStoreImportsJSON(t *testing.T) {
+	dir := t.TempDir()
+	existing := testData()
+	old := model.ArchivedTodo{ID: "y", Text: "task y", Created: existing.Archive[0].Created.AddDate(-1, 0, 0), Completed: existing.Archive[0].Completed.AddDate(-1, 0, 0)}
+	jsonStore := &JSONStore{path: filepath.Join(dir, "todos.json")}
+	if err := jsonStore.SaveCold([]model.ArchivedTodo{old}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeJSON(jsonStore.path, existing); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewEventStore(dir)
+	data, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing.Archive = append([]model.ArchivedTodo{old}, existing.Archive...)
+	if normalized(t, data) != normalized(t, existing) {
+		t.Errorf("Load() = %s, want the JSON store's data and cold storage", normalized(t, data))
+	}
+
+	// Tasks moved to cold storage after the import are still read
+	later := model.ArchivedTodo{ID: "x", Text: "task x", Created: old.Created, Completed: old.Completed}
+	if err := jsonStore.SaveCold([]model.ArchivedTodo{later}); err != nil {
+		t.Fatal(err)
+	}
+	cold, err := s.LoadCold()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cold) != 2 || cold[0].ID != "y" || cold[1].ID != "x" {
+		t.Errorf("LoadCold() = %+v, want y and x", cold)
+	}
+}
@@ -0,0 +1,234 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"upnext/internal/model"
+)
+
+// snapshotEvery is how many events are logged between snapshots
+const snapshotEvery = 200
+
+// EventStore implements Store as an append-only log of events, one JSON
+// object per line in events.jsonl. Saving logs what changed since the last
+// load or save; loading replays the log onto the latest snapshot. Since
+// each save only appends its own changes, saves from several processes
+// don't overwrite each other.
+type EventStore struct {
+	dir           string
+	snapshotEvery int
+	last          *model.Data // State as of the last Load or Save, which Save diffs against
+	pending       int         // Events logged since the last snapshot
+	repo          *Repo       // Repository to anchor tasks to, see model.Data.Anchor; nil for none
+}
+
+// snapshot is the data as of a point in the log, so loading only needs to
+// replay what came after
+type snapshot struct {
+	Offset int64       `json:"offset"` // Size of the log the snapshot covers
+	Data   *model.Data `json:"data"`
+}
+
+// NewEventStore creates an event log store in dir
+func NewEventStore(dir string) *EventStore {
+	return &EventStore{dir: dir, snapshotEvery: snapshotEvery}
+}
+
+// Dir returns the directory the store keeps its files in
+func (s *EventStore) Dir() string { return s.dir }
+
+func (s *EventStore) logPath() string      { return filepath.Join(s.dir, "events.jsonl") }
+func (s *EventStore) snapshotPath() string { return filepath.Join(s.dir, "snapshot.json") }
+
+// Load rebuilds the data from the latest snapshot and the events after it
+func (s *EventStore) Load() (*model.Data, error) {
+	data, events, _, err := s.replay()
+	if err != nil {
+		return nil, err
+	}
+	s.last = data.Clone()
+	s.pending = events
+	return data, nil
+}
+
+// Save logs the changes made to data since it was loaded or last saved,
+// taking a snapshot every so often
+func (s *EventStore) Save(data *model.Data) error {
+	if s.last == nil {
+		if _, err := s.Load(); err != nil {
+			return err
+		}
+	}
+
+	if s.repo != nil {
+		data.Anchor(s.repo.ID, s.repo.Root)
+	}
+	events := Diff(s.last, data, time.Now())
+	if len(events) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := appendFile(s.logPath(), buf.Bytes()); err != nil {
+		return err
+	}
+
+	s.last = data.Clone()
+	s.pending += len(events)
+	if s.pending >= s.snapshotEvery {
+		return s.Snapshot()
+	}
+	return nil
+}
+
+// Snapshot records the current state so loading can skip the log up to
+// here. It replays from disk rather than using what was saved last, which
+// may be missing changes other processes logged.
+func (s *EventStore) Snapshot() error {
+	data, _, offset, err := s.replay()
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(s.snapshotPath(), snapshot{Offset: offset, Data: data}); err != nil {
+		return err
+	}
+	s.pending = 0
+	return nil
+}
+
+// History returns the logged events about the task with the given ID,
+// oldest first
+func (s *EventStore) History(id string) ([]Event, error) {
+	events, _, err := readEvents(s.logPath(), 0)
+	if err != nil {
+		return nil, err
+	}
+	var history []Event
+	for _, e := range events {
+		if e.ID == id {
+			history = append(history, e)
+		}
+	}
+	return history, nil
+}
+
+// replay rebuilds the data from the latest snapshot and the log after it,
+// and returns how many events that took and the offset in the log after
+// the last one. Events other processes append meanwhile come after it.
+func (s *EventStore) replay() (*model.Data, int, int64, error) {
+	data, offset, err := s.base()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	events, offset, err := readEvents(s.logPath(), offset)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	for _, e := range events {
+		Apply(data, e)
+	}
+	return data, len(events), offset, nil
+}
+
+// base returns the data the log replays onto: the latest snapshot, or the
+// JSON store's file and cold storage when switching over from it
+func (s *EventStore) base() (*model.Data, int64, error) {
+	raw, err := os.ReadFile(s.snapshotPath())
+	if err == nil {
+		var snap snapshot
+		if err := json.Unmarshal(raw, &snap); err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", s.snapshotPath(), err)
+		}
+		if snap.Data == nil {
+			snap.Data = model.NewData()
+		}
+		return snap.Data, snap.Offset, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, 0, err
+	}
+
+	data, err := s.cold().Load()
+	if err != nil {
+		return nil, 0, err
+	}
+	cold, err := s.cold().LoadCold()
+	if err != nil {
+		return nil, 0, err
+	}
+	data.Archive = model.MergeArchive(cold, data.Archive)
+	return data, 0, nil
+}
+
+// cold returns the JSON store in the same directory, whose cold storage
+// the event store shares
+func (s *EventStore) cold() *JSONStore {
+	return &JSONStore{path: filepath.Join(s.dir, "todos.json"), repo: s.repo}
+}
+
+// LoadCold reads the completed tasks the JSON store moved to cold storage.
+// The first load imports them into the log, but any moved there after
+// that, say while the config was switched back to the JSON store, are
+// only found here.
+func (s *EventStore) LoadCold() ([]model.ArchivedTodo, error) {
+	return s.cold().LoadCold()
+}
+
+// SaveCold adds completed tasks to cold storage
+func (s *EventStore) SaveCold(items []model.ArchivedTodo) error {
+	return s.cold().SaveCold(items)
+}
+
+// DeleteCold removes completed tasks from cold storage
+func (s *EventStore) DeleteCold(items []model.ArchivedTodo) error {
+	return s.cold().DeleteCold(items)
+}
+
+// readEvents reads the events in the log from offset on, and returns the
+// offset after the last one. A last line cut short by a crash is left out.
+func readEvents(path string, offset int64) ([]Event, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, offset, nil
+		}
+		return nil, offset, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+
+	var events []Event
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			return events, offset, nil
+		}
+		if err != nil {
+			return nil, offset, err
+		}
+		offset += int64(len(line))
+		var e Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			// Ended by the next append after a crash cut it short
+			continue
+		}
+		events = append(events, e)
+	}
+}
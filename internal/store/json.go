@@ -5,22 +5,28 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"upnext/internal/model"
 )
 
 // JSONStore implements Store using a JSON file
 type JSONStore struct {
-	path string
+	path      string
+	retention time.Duration // Age at which completed tasks move to cold storage, 0 for never
+	repo      *Repo         // Repository to anchor tasks to, see model.Data.Anchor; nil for none
+	last      *model.Data   // The data as last loaded or saved, to tell which fields Save changes
 }
 
-// NewJSONStore creates a new JSON file store at the XDG-compliant path
-func NewJSONStore() (*JSONStore, error) {
+// NewJSONStore creates a new JSON file store at the XDG-compliant path,
+// moving completed tasks older than retention to cold storage when saving
+// (0 for never)
+func NewJSONStore(retention time.Duration) (*JSONStore, error) {
 	path, err := getDataPath()
 	if err != nil {
 		return nil, err
 	}
-	return &JSONStore{path: path}, nil
+	return &JSONStore{path: path, retention: retention}, nil
 }
 
 // getDataPath returns the XDG-compliant path for the data file
@@ -64,6 +70,7 @@ func (s *JSONStore) Load() (*model.Data, error) {
 	data, err := os.ReadFile(s.path)
 	if err != nil {
 		if os.IsNotExist(err) {
+			s.last = model.NewData()
 			return model.NewData(), nil
 		}
 		return nil, err
@@ -74,29 +81,54 @@ func (s *JSONStore) Load() (*model.Data, error) {
 		return nil, err
 	}
 
+	s.last = result.Clone()
 	return &result, nil
 }
 
-// Save writes the data to the JSON file atomically
+// Save writes the data to the JSON file atomically, first anchoring tasks
+// to the repository, if any, stamping the fields changed since the last
+// load or save with the time, for merging, and moving completed tasks past
+// the retention period to cold storage
 func (s *JSONStore) Save(data *model.Data) error {
+	now := time.Now()
+	if s.repo != nil {
+		data.Anchor(s.repo.ID, s.repo.Root)
+	}
+	if s.last != nil {
+		data.StampEdits(s.last, now)
+	}
+	retired, err := s.retire(data, now)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(s.path, data); err != nil {
+		s.unretire(data, retired)
+		return err
+	}
+	s.last = data.Clone()
+	return nil
+}
+
+// writeJSON writes v to path atomically
+func writeJSON(path string, v any) error {
 	// Ensure directory exists
-	dir := filepath.Dir(s.path)
+	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
 	// Marshal data
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	jsonData, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return err
 	}
 
 	// Write to temp file first for atomic operation
-	tempPath := s.path + ".tmp"
+	tempPath := path + ".tmp"
 	if err := os.WriteFile(tempPath, jsonData, 0644); err != nil {
 		return err
 	}
 
 	// Rename temp file to actual file (atomic on most systems)
-	return os.Rename(tempPath, s.path)
+	return os.Rename(tempPath, path)
 }
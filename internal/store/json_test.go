@@ -0,0 +1,75 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"upnext/internal/config"
+	"upnext/internal/model"
+)
+
+// BenchmarkAdd times what `upnext add` does to the data file, loading it,
+// adding a task and saving it, with 50k completed tasks in the history.
+// With the default retention, or any like it, most of them live in cold
+// storage and an add stays well under the 100ms the spec asks for.
+func BenchmarkAdd(b *testing.B) {
+	for _, bench := range []struct {
+		name      string
+		retention time.Duration
+	}{
+		{"default", config.Default().Archive.Retention()},
+		{"retention=30d", 30 * 24 * time.Hour},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			s := benchStore(b, bench.retention, 50000)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				data, err := s.Load()
+				if err != nil {
+					b.Fatal(err)
+				}
+				data.Items = append(data.Items, model.Todo{ID: model.GenerateID(), Text: "benchmark task", Created: time.Now()})
+				if err := s.Save(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Milliseconds())/float64(b.N), "ms/add")
+		})
+	}
+}
+
+// benchStore sets up a store whose history holds archived completed
+// tasks, one every half hour back from now
+func benchStore(b *testing.B, retention time.Duration, archived int) *JSONStore {
+	b.Helper()
+	b.Setenv("XDG_DATA_HOME", b.TempDir())
+	s, err := NewJSONStore(retention)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	now := time.Now()
+	data := model.NewData()
+	for i := 0; i < 50; i++ {
+		data.Items = append(data.Items, model.Todo{ID: fmt.Sprintf("active-%d", i), Text: "an active task", Created: now})
+	}
+	for i := archived; i > 0; i-- {
+		completed := now.Add(-time.Duration(i) * 30 * time.Minute)
+		data.Archive = append(data.Archive, model.ArchivedTodo{
+			ID:        fmt.Sprintf("done-%d", i),
+			Text:      "a task completed a while ago",
+			Tags:      []string{"bench"},
+			Created:   completed.Add(-24 * time.Hour),
+			Completed: completed,
+			Context:   "/home/user/project",
+		})
+	}
+	data.Stats.TotalCompleted = archived
+
+	// The first save moves old tasks to cold storage
+	if err := s.Save(data); err != nil {
+		b.Fatal(err)
+	}
+	return s
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"upnext/internal/model"
+)
+
+// MergeFiles merges the data files ours and theirs, which both changed
+// base, and writes the result to ours, the way git expects of a merge
+// driver. Where both changed a field, the edit made last is kept, ours
+// when that can't be told. An empty or missing base is taken as no tasks,
+// for files added on both sides.
+func MergeFiles(base, ours, theirs string) ([]model.Conflict, error) {
+	var versions [3]*model.Data
+	for i, path := range []string{base, ours, theirs} {
+		data, err := readData(path)
+		if err != nil {
+			return nil, err
+		}
+		versions[i] = data
+	}
+
+	merged, conflicts := model.Merge(versions[0], versions[1], versions[2])
+	if err := writeJSON(ours, merged); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// readData reads a data file, empty if it is missing or blank
+func readData(path string) (*model.Data, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	data := model.NewData()
+	if len(raw) == 0 {
+		return data, nil
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"upnext/internal/model"
+)
+
+func TestMergeFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := testData()
+	ours, theirs := base.Clone(), base.Clone()
+	ours.Items[0].Text = "ours"
+	ours.Items = append(ours.Items, model.Todo{ID: "e", Text: "task e"})
+	theirs.Items[0].Text = "theirs"
+	theirs.Items[1].Priority = model.PriorityHigh
+
+	paths := map[string]*model.Data{"base": base, "ours": ours, "theirs": theirs}
+	for name, data := range paths {
+		if err := writeJSON(filepath.Join(dir, name), data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conflicts, err := MergeFiles(filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Field != "text" || conflicts[0].Kept != model.Ours {
+		t.Errorf("conflicts = %+v, want the text of a, keeping ours", conflicts)
+	}
+
+	merged, err := readData(filepath.Join(dir, "ours"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged.Items) != 5 || merged.Items[0].Text != "ours" || merged.Items[1].Priority != model.PriorityHigh {
+		t.Errorf("merged items = %+v, want both sides' changes with a's text from ours", merged.Items)
+	}
+}
+
+func TestMergeFilesEmptyBase(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "base"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	ours := testData()
+	theirs := model.NewData()
+	theirs.Items = append(theirs.Items, model.Todo{ID: "x", Text: "task x"})
+	for name, data := range map[string]*model.Data{"ours": ours, "theirs": theirs} {
+		if err := writeJSON(filepath.Join(dir, name), data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := MergeFiles(filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs")); err != nil {
+		t.Fatal(err)
+	}
+	merged, err := readData(filepath.Join(dir, "ours"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged.Items) != 5 || len(merged.Archive) != 1 {
+		t.Errorf("merged %d tasks and %d completed, want 5 and 1", len(merged.Items), len(merged.Archive))
+	}
+}
@@ -0,0 +1,360 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"upnext/internal/model"
+)
+
+// Names of a project's task file, found by walking up from the working
+// directory the way git finds .git
+const (
+	ProjectFile = ".upnext.json" // A single file in the project's root
+	ProjectDir  = ".upnext"      // A directory in the project's root, holding todos.json
+)
+
+// FindProject looks for a project's task file in dir and the directories
+// above it, and returns its path and the project's root directory
+func FindProject(dir string) (path, root string, ok bool) {
+	dir = filepath.Clean(dir)
+	for {
+		if info, err := os.Stat(filepath.Join(dir, ProjectFile)); err == nil && !info.IsDir() {
+			return filepath.Join(dir, ProjectFile), dir, true
+		}
+		if info, err := os.Stat(filepath.Join(dir, ProjectDir)); err == nil && info.IsDir() {
+			return filepath.Join(dir, ProjectDir, "todos.json"), dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+		dir = parent
+	}
+}
+
+// InitProject creates an empty project task file in dir, as a single
+// file or, with asDir, a directory, and returns its path
+func InitProject(dir string, asDir bool) (string, error) {
+	for _, name := range []string{ProjectFile, ProjectDir} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return "", fmt.Errorf("%s already exists", filepath.Join(dir, name))
+		}
+	}
+	path := filepath.Join(dir, ProjectFile)
+	if asDir {
+		path = filepath.Join(dir, ProjectDir, "todos.json")
+	}
+	return path, writeJSON(path, model.NewData())
+}
+
+// Sourced is a Store that combines tasks kept in more than one file
+type Sourced interface {
+	Store
+	// Source names the file a task with the given ID and context is kept in
+	Source(id, context string) string
+}
+
+// ProjectStore implements Store over a project's task file and the global
+// store. Loading combines the two; saving splits them back up. Tasks stay
+// in the file they were loaded from, except that a project task moved to a
+// context outside the project goes to the global store. New tasks go to
+// the project when their context is inside it.
+//
+// How the two files' tasks interleave is kept for each project in
+// project-order.json, next to the global store's files, as it is only the
+// user's own; without it, project tasks come first.
+//
+// Contexts in the project file are relative to the project's root, so it
+// can be checked in and shared. Completed project tasks stay in the
+// project file rather than moving to cold storage, and count in the
+// project file's stats as well as the global ones.
+type ProjectStore struct {
+	root    string
+	project *JSONStore
+	global  Store
+	ids     map[string]bool // Whether each task loaded is from the project file
+	done    map[string]bool // Project tasks completed as of the last load or save
+	stats   model.Stats     // The project file's own stats
+}
+
+// NewProjectStore combines the project task file at path, for the project
+// in root, with the global store
+func NewProjectStore(path, root string, global Store) *ProjectStore {
+	return &ProjectStore{root: root, project: &JSONStore{path: path}, global: global, ids: map[string]bool{}, done: map[string]bool{}}
+}
+
+// Root returns the project's root directory
+func (s *ProjectStore) Root() string {
+	return s.root
+}
+
+// Load reads both files and combines them
+func (s *ProjectStore) Load() (*model.Data, error) {
+	project, err := s.project.Load()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.project.path, err)
+	}
+	data, err := s.global.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	s.ids = map[string]bool{}
+	s.done = map[string]bool{}
+	s.stats = project.Stats
+	for i := range project.Items {
+		project.Items[i].Context = s.absContext(project.Items[i].Context)
+		s.ids[project.Items[i].ID] = true
+	}
+	for i := range project.Archive {
+		project.Archive[i].Context = s.absContext(project.Archive[i].Context)
+		s.ids[project.Archive[i].ID] = true
+		s.done[project.Archive[i].ID] = true
+	}
+
+	for _, item := range data.Items {
+		s.ids[item.ID] = false
+	}
+	for _, item := range data.Archive {
+		s.ids[item.ID] = false
+	}
+
+	data.Items = interleave(project.Items, data.Items, s.loadOrder())
+	for i := range data.Items {
+		data.Items[i].Position = i
+	}
+	data.Archive = append(data.Archive, project.Archive...)
+	sort.SliceStable(data.Archive, func(i, j int) bool {
+		return data.Archive[i].Completed.Before(data.Archive[j].Completed)
+	})
+	return data, nil
+}
+
+// Save splits data between the two files and writes both. The global file
+// goes first: a task moving out of the project is then in both files
+// should the project file fail to save, rather than in neither.
+func (s *ProjectStore) Save(data *model.Data) error {
+	project := model.NewData()
+	project.Stats = s.stats
+	global := model.NewData()
+	global.Version = data.Version
+	global.Stats = data.Stats
+
+	ids := map[string]bool{}
+	for _, item := range data.Items {
+		if s.inProject(item.ID, item.Context) {
+			item.Context = s.relContext(item.Context)
+			item.Position = len(project.Items)
+			project.Items = append(project.Items, item)
+			ids[item.ID] = true
+		} else {
+			item.Position = len(global.Items)
+			global.Items = append(global.Items, item)
+			ids[item.ID] = false
+		}
+	}
+	done := map[string]bool{}
+	for _, item := range data.Archive {
+		if s.inProject(item.ID, item.Context) {
+			if !s.done[item.ID] {
+				project.Stats.TotalCompleted++
+			}
+			item.Context = s.relContext(item.Context)
+			project.Archive = append(project.Archive, item)
+			ids[item.ID] = true
+			done[item.ID] = true
+		} else {
+			global.Archive = append(global.Archive, item)
+			ids[item.ID] = false
+		}
+	}
+
+	if err := s.global.Save(global); err != nil {
+		return err
+	}
+	if err := s.project.Save(project); err != nil {
+		return err
+	}
+	if err := s.saveOrder(data.Items); err != nil {
+		return err
+	}
+
+	// Saving may have moved old completed tasks to cold storage; they
+	// leave data too, as they do with the global store on its own
+	kept := map[string]bool{}
+	for _, item := range global.Archive {
+		kept[item.ID] = true
+	}
+	data.TakeArchived(func(a model.ArchivedTodo) bool {
+		return !ids[a.ID] && !kept[a.ID]
+	})
+	s.ids = ids
+	s.done = done
+	s.stats = project.Stats
+	return nil
+}
+
+// orderFile is where the combined order of each project's tasks is kept
+const orderFile = "project-order.json"
+
+// orderPath returns the path of orderFile, "" when the global store has no
+// directory to keep it in
+func (s *ProjectStore) orderPath() string {
+	if d, ok := s.global.(interface{ Dir() string }); ok {
+		return filepath.Join(d.Dir(), orderFile)
+	}
+	return ""
+}
+
+// loadOrders reads the combined order of every project's active tasks, as
+// IDs by project root
+func (s *ProjectStore) loadOrders() (map[string][]string, error) {
+	orders := map[string][]string{}
+	raw, err := os.ReadFile(s.orderPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return orders, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &orders); err != nil {
+		return nil, fmt.Errorf("%s: %w", orderFile, err)
+	}
+	return orders, nil
+}
+
+// loadOrder returns the IDs of the project's active tasks and the global
+// ones in the order they were last saved in, none if it can't be read
+func (s *ProjectStore) loadOrder() []string {
+	if s.orderPath() == "" {
+		return nil
+	}
+	orders, _ := s.loadOrders()
+	return orders[s.root]
+}
+
+// saveOrder records the order of the combined active tasks
+func (s *ProjectStore) saveOrder(items []model.Todo) error {
+	if s.orderPath() == "" {
+		return nil
+	}
+	orders, err := s.loadOrders()
+	if err != nil {
+		// Rather than keep failing, start over
+		orders = map[string][]string{}
+	}
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	orders[s.root] = ids
+	return writeJSON(s.orderPath(), orders)
+}
+
+// interleave combines the project's active tasks and the global ones,
+// keeping each file's own order and fitting them together by order, the
+// IDs as last saved. A task missing from order follows the one before it
+// in its file, or precedes the one after it; project tasks go first when
+// there is nothing to go by.
+func interleave(project, global []model.Todo, order []string) []model.Todo {
+	rank := make(map[string]int, len(order))
+	for i, id := range order {
+		rank[id] = i
+	}
+	p, g := ranks(project, rank, -1), ranks(global, rank, len(order))
+
+	combined := make([]model.Todo, 0, len(project)+len(global))
+	i, j := 0, 0
+	for i < len(project) || j < len(global) {
+		if j == len(global) || (i < len(project) && p[i] <= g[j]) {
+			combined = append(combined, project[i])
+			i++
+		} else {
+			combined = append(combined, global[j])
+			j++
+		}
+	}
+	return combined
+}
+
+// ranks places each of items by rank, see interleave, with fallback for a
+// file none of whose tasks are ranked
+func ranks(items []model.Todo, rank map[string]int, fallback int) []int {
+	r := make([]int, len(items))
+	prev, ranked := fallback, false
+	for i, item := range items {
+		if at, ok := rank[item.ID]; ok {
+			if !ranked {
+				// Tasks before the first ranked one go just before it
+				for k := 0; k < i; k++ {
+					r[k] = at
+				}
+			}
+			prev, ranked = at, true
+		}
+		r[i] = prev
+	}
+	return r
+}
+
+// Source names the file a task is kept in: the project's directory name,
+// or "global"
+func (s *ProjectStore) Source(id, context string) string {
+	if s.inProject(id, context) {
+		return filepath.Base(s.root)
+	}
+	return "global"
+}
+
+// LoadCold reads the global store's cold storage
+func (s *ProjectStore) LoadCold() ([]model.ArchivedTodo, error) {
+	if cs, ok := s.global.(ColdStore); ok {
+		return cs.LoadCold()
+	}
+	return nil, nil
+}
+
+// SaveCold adds completed tasks to the global store's cold storage
+func (s *ProjectStore) SaveCold(items []model.ArchivedTodo) error {
+	if cs, ok := s.global.(ColdStore); ok {
+		return cs.SaveCold(items)
+	}
+	return nil
+}
+
+// DeleteCold removes completed tasks from the global store's cold storage
+func (s *ProjectStore) DeleteCold(items []model.ArchivedTodo) error {
+	if cs, ok := s.global.(ColdStore); ok {
+		return cs.DeleteCold(items)
+	}
+	return nil
+}
+
+// inProject reports whether a task belongs in the project file
+func (s *ProjectStore) inProject(id, context string) bool {
+	inside := context == s.root || strings.HasPrefix(context, s.root+string(filepath.Separator))
+	if project, ok := s.ids[id]; ok {
+		return project && inside
+	}
+	return inside
+}
+
+// absContext turns a context as stored in the project file into a directory
+func (s *ProjectStore) absContext(context string) string {
+	return filepath.Join(s.root, filepath.FromSlash(context))
+}
+
+// relContext turns a directory in the project into a context as stored in
+// the project file, "" for the root
+func (s *ProjectStore) relContext(context string) string {
+	rel, err := filepath.Rel(s.root, context)
+	if err != nil || rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,231 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"upnext/internal/model"
+)
+
+func TestFindProject(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := FindProject(sub); ok {
+		t.Fatal("FindProject found a project before there was one")
+	}
+
+	path, err := InitProject(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, gotRoot, ok := FindProject(sub); !ok || got != path || gotRoot != root {
+		t.Errorf("FindProject(sub) = %q, %q, %v; want %q, %q", got, gotRoot, ok, path, root)
+	}
+	if _, err := InitProject(root, true); err == nil {
+		t.Error("InitProject over an existing project succeeded")
+	}
+
+	// A nearer project wins
+	nested := filepath.Join(root, "a")
+	path, err = InitProject(nested, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, gotRoot, ok := FindProject(sub); !ok || got != path || gotRoot != nested {
+		t.Errorf("FindProject(sub) = %q, %q, %v; want %q, %q", got, gotRoot, ok, path, nested)
+	}
+}
+
+func TestProjectStore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "proj")
+	path, err := InitProject(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	global := &JSONStore{path: filepath.Join(t.TempDir(), "todos.json")}
+
+	// A global task, and one in the project from before it had a file
+	existing := model.NewData()
+	existing.Items = []model.Todo{
+		{ID: "g", Text: "global", Context: ""},
+		{ID: "old", Text: "old", Context: root},
+	}
+	if err := global.Save(existing); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewProjectStore(path, root, global)
+	data, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.Items = append([]model.Todo{
+		{ID: "p", Text: "project", Context: filepath.Join(root, "cmd")},
+		{ID: "top", Text: "at the root", Context: root},
+	}, data.Items...)
+	data.Items = append(data.Items, model.Todo{ID: "elsewhere", Text: "elsewhere", Context: "/somewhere/else"})
+	if err := s.Save(data); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), root) {
+		t.Errorf("project file holds absolute contexts:\n%s", raw)
+	}
+	ids := func(items []model.Todo) string {
+		var s []string
+		for _, item := range items {
+			s = append(s, item.ID)
+		}
+		return strings.Join(s, " ")
+	}
+	project, err := (&JSONStore{path: path}).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(project.Items); got != "p top" {
+		t.Errorf("project file has %q, want the new tasks inside the project", got)
+	}
+	if project.Items[0].Context != "cmd" || project.Items[1].Context != "" {
+		t.Errorf("project contexts = %q, %q; want cmd and the root", project.Items[0].Context, project.Items[1].Context)
+	}
+	globalData, err := global.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(globalData.Items); got != "g old elsewhere" {
+		t.Errorf("global file has %q, want the tasks it had and the one outside the project", got)
+	}
+
+	// Reloading gives back absolute contexts, project tasks first
+	data, err = NewProjectStore(path, root, global).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(data.Items); got != "p top g old elsewhere" {
+		t.Errorf("Load() = %q", got)
+	}
+	if data.Items[0].Context != filepath.Join(root, "cmd") {
+		t.Errorf("project task context = %q, want it under %s", data.Items[0].Context, root)
+	}
+	if got, want := s.Source("p", data.Items[0].Context), "proj"; got != want {
+		t.Errorf("Source(p) = %q, want %q", got, want)
+	}
+	if got := s.Source("old", root); got != "global" {
+		t.Errorf("Source(old) = %q, want global", got)
+	}
+
+	// Completing a project task keeps it in the project file
+	data.Complete(0, time.Now())
+	if err := s.Save(data); err != nil {
+		t.Fatal(err)
+	}
+	project, err = (&JSONStore{path: path}).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(project.Archive) != 1 || project.Archive[0].ID != "p" {
+		t.Errorf("project archive = %+v, want p", project.Archive)
+	}
+	globalData, err = global.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project.Stats.TotalCompleted != 1 || globalData.Stats.TotalCompleted != 1 {
+		t.Errorf("completed count = %d in the project, %d globally; want it counted in both",
+			project.Stats.TotalCompleted, globalData.Stats.TotalCompleted)
+	}
+
+	// Saving again doesn't count it twice
+	if err := s.Save(data); err != nil {
+		t.Fatal(err)
+	}
+	if project, err = (&JSONStore{path: path}).Load(); err != nil {
+		t.Fatal(err)
+	}
+	if project.Stats.TotalCompleted != 1 {
+		t.Errorf("completed count after saving again = %d, want 1", project.Stats.TotalCompleted)
+	}
+}
+
+func TestProjectStoreOrder(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "proj")
+	path, err := InitProject(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	global := &JSONStore{path: filepath.Join(t.TempDir(), "todos.json")}
+	existing := model.NewData()
+	existing.Items = []model.Todo{{ID: "g1", Context: "/elsewhere"}, {ID: "g2", Context: "/elsewhere"}}
+	if err := global.Save(existing); err != nil {
+		t.Fatal(err)
+	}
+	ids := func(items []model.Todo) string {
+		var s []string
+		for _, item := range items {
+			s = append(s, item.ID)
+		}
+		return strings.Join(s, " ")
+	}
+	reload := func() *model.Data {
+		t.Helper()
+		data, err := NewProjectStore(path, root, global).Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	s := NewProjectStore(path, root, global)
+	data, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.Items = append(data.Items, model.Todo{ID: "p1", Context: root}, model.Todo{ID: "p2", Context: root})
+	if err := s.Save(data); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(reload().Items); got != "g1 g2 p1 p2" {
+		t.Errorf("after adding below the global tasks, Load() = %q", got)
+	}
+
+	// Moving tasks past ones from the other file sticks
+	data.MoveTodo(2, 0, false)
+	data.MoveTodo(3, 1, false)
+	if err := s.Save(data); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(reload().Items); got != "p1 p2 g1 g2" {
+		t.Errorf("after moving the project tasks up, Load() = %q", got)
+	}
+	data.MoveTodo(2, 1, false)
+	data.Items = append([]model.Todo{{ID: "new", Context: ""}}, data.Items...)
+	if err := s.Save(data); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(reload().Items); got != "new p1 g1 p2 g2" {
+		t.Errorf("after interleaving and adding a global task on top, Load() = %q", got)
+	}
+
+	// A task added to the project file elsewhere follows the one before it there
+	project, err := (&JSONStore{path: path}).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	project.Items = append(project.Items, model.Todo{ID: "p3"})
+	if err := writeJSON(path, project); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(reload().Items); got != "new p1 g1 p2 p3 g2" {
+		t.Errorf("with a task new to the project file, Load() = %q", got)
+	}
+}
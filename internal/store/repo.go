@@ -0,0 +1,86 @@
+package store
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Repo is a git repository checked out on this machine
+type Repo struct {
+	ID   string // Stays the same wherever it is cloned: its remote, or else its first commit
+	Root string // Where it is checked out
+}
+
+// FindRepo returns the git repository dir is in. A repository with no
+// remote and no commits has nothing to identify it by, and isn't found.
+func FindRepo(dir string) (Repo, bool) {
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil || root == "" {
+		return Repo{}, false
+	}
+	if remote, err := git(root, "remote", "get-url", "origin"); err == nil && remote != "" {
+		return Repo{ID: normalizeRemote(remote), Root: root}, true
+	}
+	commits, err := git(root, "rev-list", "--max-parents=0", "HEAD")
+	if err != nil || commits == "" {
+		return Repo{}, false
+	}
+	first, _, _ := strings.Cut(commits, "\n")
+	return Repo{ID: "commit:" + first, Root: root}, true
+}
+
+// normalizeRemote reduces the ways of writing a remote to one, so cloning
+// over SSH or HTTPS gives the same ID: github.com/me/api for
+// git@github.com:me/api.git and https://github.com/me/api alike
+func normalizeRemote(remote string) string {
+	id := remote
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		id = strings.ToLower(u.Hostname()) + u.Path
+	} else if host, path, ok := strings.Cut(remote, ":"); ok && !strings.Contains(host, "/") && len(host) > 1 {
+		// scp-like syntax, [user@]host:path
+		if _, h, ok := strings.Cut(host, "@"); ok {
+			host = h
+		}
+		id = strings.ToLower(host) + "/" + strings.TrimPrefix(path, "/")
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(id, "/"), ".git")
+}
+
+// What became of a branch, see BranchState
+const (
+	BranchCurrent = "current" // Checked out
+	BranchOpen    = "open"    // Not checked out, and not merged into the branch that is
+	BranchMerged  = "merged"  // Merged into the branch checked out
+	BranchDeleted = "deleted" // No longer exists
+	BranchUnknown = "unknown" // Its repository is gone, or can't be read
+)
+
+// BranchTip returns the commit a branch of the repository dir is in is at
+func BranchTip(dir, branch string) (string, error) {
+	return git(dir, "rev-parse", "--verify", "-q", "refs/heads/"+branch)
+}
+
+// BranchState says what became of a branch of the repository dir is in.
+// tip is the commit the branch was at when the task was added: a branch
+// that hasn't moved past it has no work of its own yet, so it isn't
+// called merged just because the branch checked out contains it.
+func BranchState(dir, branch, tip string) (string, error) {
+	if current, err := git(dir, "symbolic-ref", "--short", "-q", "HEAD"); err == nil && current == branch {
+		return BranchCurrent, nil
+	}
+	if _, err := git(dir, "rev-parse", "--show-toplevel"); err != nil {
+		return "", err
+	}
+	ref := "refs/heads/" + branch
+	at, err := git(dir, "rev-parse", "--verify", "-q", ref)
+	if err != nil {
+		return BranchDeleted, nil
+	}
+	if at == tip {
+		return BranchOpen, nil
+	}
+	if _, err := git(dir, "merge-base", "--is-ancestor", ref, "HEAD"); err == nil {
+		return BranchMerged, nil
+	}
+	return BranchOpen, nil
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeRemote(t *testing.T) {
+	for _, remote := range []string{
+		"git@github.com:me/api.git",
+		"https://github.com/me/api",
+		"https://user@GitHub.com/me/api.git",
+		"ssh://git@github.com:22/me/api.git",
+		"github.com:me/api/",
+	} {
+		if got := normalizeRemote(remote); got != "github.com/me/api" {
+			t.Errorf("normalizeRemote(%q) = %q, want github.com/me/api", remote, got)
+		}
+	}
+	if got := normalizeRemote("/srv/git/api.git"); got != "/srv/git/api" {
+		t.Errorf("normalizeRemote of a path = %q", got)
+	}
+}
+
+func TestFindRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run("init", "-q")
+	if _, ok := FindRepo(root); ok {
+		t.Error("FindRepo found a repository with nothing to identify it by")
+	}
+
+	run("-c", "user.name=t", "-c", "user.email=t@t", "commit", "-q", "--allow-empty", "-m", "first")
+	repo, ok := FindRepo(root)
+	if !ok || !strings.HasPrefix(repo.ID, "commit:") {
+		t.Errorf("FindRepo() = %+v, %v; want the first commit", repo, ok)
+	}
+
+	run("remote", "add", "origin", "git@example.com:me/api.git")
+	repo, ok = FindRepo(root)
+	if !ok || repo.ID != "example.com/me/api" {
+		t.Errorf("FindRepo() = %+v, %v; want the remote", repo, ok)
+	}
+	want, _ := filepath.EvalSymlinks(root)
+	if got, _ := filepath.EvalSymlinks(repo.Root); got != want {
+		t.Errorf("root = %q, want %q", repo.Root, root)
+	}
+}
+
+func TestBranchState(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@t"}, args...)...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run("init", "-q", "-b", "main")
+	run("commit", "-q", "--allow-empty", "-m", "first")
+	start, err := BranchTip(root, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	run("branch", "fresh")
+	run("checkout", "-q", "-b", "merged")
+	run("commit", "-q", "--allow-empty", "-m", "done")
+	run("checkout", "-q", "-b", "open")
+	run("commit", "-q", "--allow-empty", "-m", "work")
+	run("checkout", "-q", "main")
+	run("merge", "-q", "--ff-only", "merged")
+
+	for _, tt := range []struct{ branch, tip, want string }{
+		{"main", start, BranchCurrent},
+		{"merged", start, BranchMerged},
+		{"open", start, BranchOpen},
+		{"deleted", start, BranchDeleted},
+		// No commits of its own yet, though main contains it
+		{"fresh", start, BranchOpen},
+		// Added before tips were recorded
+		{"fresh", "", BranchMerged},
+	} {
+		if got, err := BranchState(root, tt.branch, tt.tip); err != nil || got != tt.want {
+			t.Errorf("BranchState(%q, %.7q) = %q, %v; want %q", tt.branch, tt.tip, got, err, tt.want)
+		}
+	}
+	if _, err := BranchState(t.TempDir(), "main", ""); err == nil {
+		t.Error("BranchState outside a repository succeeded")
+	}
+}
@@ -1,6 +1,12 @@
 package store
 
-import "upnext/internal/model"
+import (
+	"os"
+	"path/filepath"
+
+	"upnext/internal/config"
+	"upnext/internal/model"
+)
 
 // Store defines the interface for persisting todo data
 type Store interface {
@@ -9,3 +15,80 @@ type Store interface {
 	// Save writes the data to storage
 	Save(data *model.Data) error
 }
+
+// ColdStore is a Store that keeps older completed tasks out of the data
+// Load returns, to be read only when the full history is wanted
+type ColdStore interface {
+	Store
+	// LoadCold reads the completed tasks in cold storage, oldest first
+	LoadCold() ([]model.ArchivedTodo, error)
+	// SaveCold adds completed tasks to cold storage, or updates them there
+	SaveCold(items []model.ArchivedTodo) error
+	// DeleteCold removes completed tasks from cold storage
+	DeleteCold(items []model.ArchivedTodo) error
+}
+
+// Open returns the store for the working directory: the global store,
+// combined with the project's task file when inside a project
+func Open() (Store, error) {
+	global, err := OpenGlobal()
+	if err != nil {
+		return nil, err
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if path, root, ok := FindProject(cwd); ok {
+			return NewProjectStore(path, root, global), nil
+		}
+	}
+	return global, nil
+}
+
+// OpenGlobal returns the global store the config file picks: the JSON file
+// by default, or the event log. With [context] anchor = "repo", tasks are
+// anchored to the git repository of the working directory when saved.
+func OpenGlobal() (Store, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	path, err := getDataPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var repo *Repo
+	if cwd, err := os.Getwd(); err == nil {
+		repo = anchorRepo(cfg, cwd)
+	}
+
+	if cfg.Store.Format == config.StoreEvents {
+		s := NewEventStore(filepath.Dir(path))
+		s.repo = repo
+		return s, nil
+	}
+	return &JSONStore{path: path, retention: cfg.Archive.Retention(), repo: repo}, nil
+}
+
+// CheckoutOf returns the checkout of the git repository dir is in, for
+// matching tasks anchored to it with model.Todo.ContextIn, when the config
+// file anchors tasks to repositories. Otherwise it returns none, and tasks
+// are matched by their contexts alone.
+func CheckoutOf(dir string) model.Checkout {
+	cfg, _ := config.Load()
+	if repo := anchorRepo(cfg, dir); repo != nil {
+		return model.Checkout{Repo: repo.ID, Root: repo.Root}
+	}
+	return model.Checkout{}
+}
+
+// anchorRepo returns the repository to anchor tasks added in dir to, if the
+// config anchors them to repositories and dir is in one
+func anchorRepo(cfg config.Config, dir string) *Repo {
+	if cfg.Context.Anchor != config.AnchorRepo || dir == "" {
+		return nil
+	}
+	if repo, ok := FindRepo(dir); ok {
+		return &repo
+	}
+	return nil
+}
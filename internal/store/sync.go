@@ -0,0 +1,279 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"upnext/internal/model"
+)
+
+// Syncing keeps the data directory in a git repository and exchanges
+// commits with a remote. Where both sides changed, the tasks are merged
+// with model.Merge rather than as text, and the cold storage segments,
+// which are only ever appended to, by taking the lines of both.
+
+// SyncResult says what a sync did
+type SyncResult struct {
+	Committed bool             // Local changes were committed
+	Pulled    bool             // Changes from the remote were brought in
+	Pushed    bool             // Local commits were sent to the remote
+	Conflicts []model.Conflict // Tasks both sides changed in different ways
+}
+
+// Dir returns the directory the store keeps its files in
+func (s *JSONStore) Dir() string {
+	return filepath.Dir(s.path)
+}
+
+// InitSync makes the data directory a git repository that syncs with
+// remote, a git URL or the path of a repository
+func (s *JSONStore) InitSync(remote string) error {
+	dir := s.Dir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		if _, err := git(dir, "init", "-q", "-b", "main"); err != nil {
+			return err
+		}
+	}
+
+	// A relative path would be taken relative to the data directory
+	if _, err := os.Stat(remote); err == nil {
+		if abs, err := filepath.Abs(remote); err == nil {
+			remote = abs
+		}
+	}
+	if _, err := git(dir, "remote", "get-url", "origin"); err == nil {
+		_, err = git(dir, "remote", "set-url", "origin", remote)
+		if err != nil {
+			return err
+		}
+	} else if _, err := git(dir, "remote", "add", "origin", remote); err != nil {
+		return err
+	}
+
+	// Commits need an author, which a machine set up only for syncing
+	// may not have
+	for key, value := range map[string]string{"user.name": "upnext", "user.email": "upnext@localhost"} {
+		if _, err := git(dir, "config", key); err != nil {
+			if _, err := git(dir, "config", key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		return os.WriteFile(ignore, []byte("*.tmp\n"), 0644)
+	}
+	return nil
+}
+
+// Sync commits local changes, merges in the remote's and pushes the result
+func (s *JSONStore) Sync() (*SyncResult, error) {
+	dir := s.Dir()
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		return nil, fmt.Errorf("%s is not set up for syncing; run 'upnext sync init <remote>' first", dir)
+	}
+
+	result := &SyncResult{}
+	host, _ := os.Hostname()
+	committed, err := commitAll(dir, "Update tasks on "+host)
+	if err != nil {
+		return nil, err
+	}
+	result.Committed = committed
+
+	branch, err := git(dir, "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := git(dir, "fetch", "-q", "origin"); err != nil {
+		return nil, err
+	}
+	remote := "refs/remotes/origin/" + branch
+	if hasRef(dir, remote) {
+		if result.Pulled, result.Conflicts, err = s.pull(remote); err != nil {
+			return nil, err
+		}
+	}
+
+	if !hasRef(dir, "HEAD") {
+		// Nothing on either side yet
+		return result, nil
+	}
+	if !hasRef(dir, remote) || count(dir, remote+"..HEAD") > 0 {
+		if _, err := git(dir, "push", "-q", "origin", "HEAD:refs/heads/"+branch); err != nil {
+			return nil, err
+		}
+		result.Pushed = true
+	}
+	return result, nil
+}
+
+// pull brings the commits on remote into the current branch, merging the
+// tasks when both sides have changed
+func (s *JSONStore) pull(remote string) (pulled bool, conflicts []model.Conflict, err error) {
+	dir := s.Dir()
+	switch {
+	case !hasRef(dir, "HEAD"):
+		_, err := git(dir, "merge", "-q", "--ff-only", remote)
+		return err == nil, nil, err
+	case isAncestor(dir, remote, "HEAD"):
+		return false, nil, nil
+	case isAncestor(dir, "HEAD", remote):
+		_, err := git(dir, "merge", "-q", "--ff-only", remote)
+		return err == nil, nil, err
+	}
+
+	// Histories that started apart have no base; everything counts as added
+	base := model.NewData()
+	if rev, err := git(dir, "merge-base", "HEAD", remote); err == nil {
+		if base, err = dataAt(dir, rev); err != nil {
+			return false, nil, err
+		}
+	}
+	ours, err := dataAt(dir, "HEAD")
+	if err != nil {
+		return false, nil, err
+	}
+	theirs, err := dataAt(dir, remote)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// Where both changed a field, the edit made last wins, by the times
+	// Save stamped on it
+	merged, conflicts := model.Merge(base, ours, theirs)
+
+	// Record the merge while keeping our files, then write the merged ones
+	if _, err := git(dir, "merge", "-q", "--no-commit", "--allow-unrelated-histories", "-s", "ours", remote); err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		if err != nil {
+			// Put the files back as they were committed, so the next sync
+			// starts over rather than finding a merge in progress
+			_, _ = git(dir, "merge", "--abort")
+		}
+	}()
+	if err := writeJSON(s.path, merged); err != nil {
+		return false, nil, err
+	}
+	if err := mergeSegments(dir, remote); err != nil {
+		return false, nil, err
+	}
+	if _, err := git(dir, "add", "-A"); err != nil {
+		return false, nil, err
+	}
+	if _, err := git(dir, "commit", "-q", "-m", "Merge tasks from origin"); err != nil {
+		return false, nil, err
+	}
+	return true, conflicts, nil
+}
+
+// mergeSegments adds the lines of remote's cold storage segments that the
+// local ones lack
+func mergeSegments(dir, remote string) error {
+	paths, err := git(dir, "ls-tree", "-r", "--name-only", remote, "--", "archive")
+	if err != nil || paths == "" {
+		return err
+	}
+	for _, path := range strings.Split(paths, "\n") {
+		theirs, err := git(dir, "show", remote+":"+path)
+		if err != nil {
+			return err
+		}
+		local := filepath.Join(dir, filepath.FromSlash(path))
+		ours, err := os.ReadFile(local)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		have := map[string]bool{}
+		for _, line := range strings.Split(string(ours), "\n") {
+			have[line] = true
+		}
+		var missing bytes.Buffer
+		for _, line := range strings.Split(theirs, "\n") {
+			if line != "" && !have[line] {
+				missing.WriteString(line + "\n")
+				have[line] = true
+			}
+		}
+		if missing.Len() > 0 {
+			if err := appendFile(local, missing.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dataAt reads todos.json as of a commit, empty if it had none
+func dataAt(dir, rev string) (*model.Data, error) {
+	if _, err := git(dir, "cat-file", "-e", rev+":todos.json"); err != nil {
+		return model.NewData(), nil
+	}
+	raw, err := git(dir, "show", rev+":todos.json")
+	if err != nil {
+		return nil, err
+	}
+	data := model.NewData()
+	if err := json.Unmarshal([]byte(raw), data); err != nil {
+		return nil, fmt.Errorf("todos.json at %s: %w", rev, err)
+	}
+	return data, nil
+}
+
+// commitAll commits every change in dir, and reports whether there were any
+func commitAll(dir, message string) (bool, error) {
+	if _, err := git(dir, "add", "-A"); err != nil {
+		return false, err
+	}
+	status, err := git(dir, "status", "--porcelain")
+	if err != nil || status == "" {
+		return false, err
+	}
+	_, err = git(dir, "commit", "-q", "-m", message)
+	return err == nil, err
+}
+
+func hasRef(dir, ref string) bool {
+	_, err := git(dir, "rev-parse", "--verify", "-q", ref)
+	return err == nil
+}
+
+func isAncestor(dir, ancestor, rev string) bool {
+	_, err := git(dir, "merge-base", "--is-ancestor", ancestor, rev)
+	return err == nil
+}
+
+func count(dir, revs string) int {
+	out, _ := git(dir, "rev-list", "--count", revs)
+	n, _ := strconv.Atoi(out)
+	return n
+}
+
+// git runs a git command in dir and returns its output, trimmed
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
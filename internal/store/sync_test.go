@@ -0,0 +1,157 @@
+package store
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"upnext/internal/model"
+)
+
+func TestSync(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	remote := filepath.Join(t.TempDir(), "tasks.git")
+	if out, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+
+	laptop := &JSONStore{path: filepath.Join(t.TempDir(), "todos.json")}
+	devbox := &JSONStore{path: filepath.Join(t.TempDir(), "todos.json")}
+	sync := func(s *JSONStore) *SyncResult {
+		t.Helper()
+		result, err := s.Sync()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	load := func(s *JSONStore) *model.Data {
+		t.Helper()
+		data, err := s.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	save := func(s *JSONStore, data *model.Data) {
+		t.Helper()
+		if err := s.Save(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The laptop starts out with tasks, the dev box with none
+	data := model.NewData()
+	for _, id := range []string{"a", "b", "c"} {
+		data.Items = append(data.Items, model.Todo{ID: id, Text: "task " + id})
+	}
+	save(laptop, data)
+	for _, s := range []*JSONStore{laptop, devbox} {
+		if err := s.InitSync(remote); err != nil {
+			t.Fatal(err)
+		}
+		sync(s)
+	}
+	if got := len(load(devbox).Items); got != 3 {
+		t.Fatalf("dev box has %d tasks after the first sync, want 3", got)
+	}
+
+	// Both change the same tasks before syncing again
+	data = load(laptop)
+	data.Items[0].Text = "laptop"
+	data.Items = append(data.Items, model.Todo{ID: "d", Text: "task d"})
+	data.Complete(1, time.Now())
+	save(laptop, data)
+	if err := laptop.SaveCold([]model.ArchivedTodo{{ID: "old", Text: "old", Completed: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}}); err != nil {
+		t.Fatal(err)
+	}
+
+	data = load(devbox)
+	data.Items[1].Priority = model.PriorityHigh
+	data.Items[2].Text = "devbox"
+	data.Items = append(data.Items, model.Todo{ID: "e", Text: "task e"})
+	save(devbox, data)
+
+	if result := sync(laptop); !result.Committed || !result.Pushed {
+		t.Errorf("laptop sync = %+v, want committed and pushed", result)
+	}
+	if result := sync(devbox); !result.Pulled || !result.Pushed || len(result.Conflicts) != 0 {
+		t.Errorf("dev box sync = %+v, want a clean merge that is pushed", result)
+	}
+	sync(laptop)
+
+	for name, s := range map[string]*JSONStore{"laptop": laptop, "dev box": devbox} {
+		data := load(s)
+		var items []string
+		for _, item := range data.Items {
+			items = append(items, item.ID+":"+item.Text)
+		}
+		if got, want := strings.Join(items, " "), "a:laptop c:devbox d:task d e:task e"; got != want {
+			t.Errorf("%s has %q, want %q", name, got, want)
+		}
+		if len(data.Archive) != 1 || data.Archive[0].ID != "b" || data.Archive[0].Priority != model.PriorityHigh {
+			t.Errorf("%s has completed %+v, want b with the dev box's priority", name, data.Archive)
+		}
+		cold, err := s.LoadCold()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cold) != 1 || cold[0].ID != "old" {
+			t.Errorf("%s has %+v in cold storage, want the laptop's", name, cold)
+		}
+	}
+
+	// A merge that fails partway is abandoned, leaving no merge in progress
+	data = load(laptop)
+	data.Items[0].Text = "laptop again"
+	save(laptop, data)
+	sync(laptop)
+	data = load(devbox)
+	data.Items[1].Text = "devbox again"
+	save(devbox, data)
+	blocker := devbox.path + ".tmp"
+	if err := os.Mkdir(blocker, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := devbox.Sync(); err == nil {
+		t.Fatal("sync succeeded without being able to write the merged tasks")
+	}
+	if hasRef(devbox.Dir(), "MERGE_HEAD") {
+		t.Error("the failed sync left a merge in progress")
+	}
+	if err := os.Remove(blocker); err != nil {
+		t.Fatal(err)
+	}
+	if result := sync(devbox); !result.Pulled {
+		t.Errorf("sync after the failure = %+v, want the remote pulled", result)
+	}
+	if got := load(devbox).Items[0].Text; got != "laptop again" {
+		t.Errorf("dev box has %q after syncing again, want the laptop's edit", got)
+	}
+	sync(laptop)
+
+	// A clash goes to the edit made last, even when the side that made the
+	// earlier one syncs last
+	data = load(laptop)
+	data.Items[2].Text = "laptop first"
+	save(laptop, data)
+	data = load(devbox)
+	data.Items[2].Text = "devbox last"
+	save(devbox, data)
+	sync(devbox)
+	result := sync(laptop)
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Field != "text" || result.Conflicts[0].Kept != model.Theirs {
+		t.Errorf("laptop sync conflicts = %+v, want the text, keeping the dev box's", result.Conflicts)
+	}
+	sync(devbox)
+	for name, s := range map[string]*JSONStore{"laptop": laptop, "dev box": devbox} {
+		if got := load(s).Items[2].Text; got != "devbox last" {
+			t.Errorf("%s has %q, want the later edit", name, got)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"upnext/internal/model"
+)
+
+// Changes that ModeBulk prompts for
+const (
+	bulkTags     = "tags"     // Add and remove tags
+	bulkPriority = "priority" // Set the priority
+	bulkMove     = "move"     // Move to another context
+)
+
+// ToggleSelect adds the task under the cursor to the selection, or takes
+// it out again
+func (m *Model) ToggleSelect() {
+	m.commitRange()
+	item, ok := m.selectedTodo()
+	if !ok || m.tab != TabActive {
+		return
+	}
+	if m.selected[item.ID] {
+		delete(m.selected, item.ID)
+	} else {
+		m.selected[item.ID] = true
+	}
+}
+
+// ToggleRange starts selecting a range of rows at the cursor, or adds the
+// rows between where it started and the cursor to the selection
+func (m *Model) ToggleRange() {
+	if m.tab != TabActive || len(m.filteredItems) == 0 {
+		return
+	}
+	if m.rangeAnchor >= 0 {
+		m.commitRange()
+		return
+	}
+	m.rangeAnchor = m.table.Cursor()
+}
+
+// commitRange adds the rows of a range in progress to the selection
+func (m *Model) commitRange() {
+	if m.rangeAnchor < 0 {
+		return
+	}
+	for i, item := range m.filteredItems {
+		if m.inRange(i) {
+			m.selected[item.ID] = true
+		}
+	}
+	m.rangeAnchor = -1
+}
+
+// inRange reports whether row i lies in the range being selected
+func (m *Model) inRange(i int) bool {
+	if m.rangeAnchor < 0 {
+		return false
+	}
+	cursor := m.table.Cursor()
+	return i >= min(m.rangeAnchor, cursor) && i <= max(m.rangeAnchor, cursor)
+}
+
+// isSelected reports whether row i of the active list is selected
+func (m *Model) isSelected(i int) bool {
+	return i < len(m.filteredItems) && (m.selected[m.filteredItems[i].ID] || m.inRange(i))
+}
+
+// SelectAll selects every visible task, or clears the selection when they
+// already are
+func (m *Model) SelectAll() {
+	if m.tab != TabActive {
+		return
+	}
+	m.rangeAnchor = -1
+	if m.SelectionCount() == len(m.filteredItems) {
+		m.ClearSelection()
+		return
+	}
+	for _, item := range m.filteredItems {
+		m.selected[item.ID] = true
+	}
+}
+
+// ClearSelection empties the selection
+func (m *Model) ClearSelection() {
+	m.selected = map[string]bool{}
+	m.rangeAnchor = -1
+}
+
+// SelectionCount returns how many visible tasks are selected
+func (m *Model) SelectionCount() int {
+	n := 0
+	for i := range m.filteredItems {
+		if m.isSelected(i) {
+			n++
+		}
+	}
+	return n
+}
+
+// bulkTargets returns the selected tasks in list order, or the task under
+// the cursor when nothing is selected
+func (m *Model) bulkTargets() []model.Todo {
+	var targets []model.Todo
+	for i, item := range m.filteredItems {
+		if m.isSelected(i) {
+			targets = append(targets, item)
+		}
+	}
+	if len(targets) == 0 {
+		if item, ok := m.selectedTodo(); ok && m.tab == TabActive {
+			targets = append(targets, item)
+		}
+	}
+	return targets
+}
+
+// beginBulk snapshots the data so the change about to be made can be
+// undone, and returns the tasks it applies to
+func (m *Model) beginBulk() []model.Todo {
+	targets := m.bulkTargets()
+	if len(targets) > 0 {
+		m.undo = m.data.Clone()
+	}
+	return targets
+}
+
+// endBulk wraps up a bulk change, describing it in the status bar
+func (m *Model) endBulk(verb string, n int) {
+	m.ClearSelection()
+	m.refreshTable()
+	m.undoLabel = fmt.Sprintf("%s %d %s", verb, n, model.Plural(n, "task"))
+	m.notice = m.undoLabel + " · U to undo"
+}
+
+// indexOf returns the position of the task with the given ID in m.data.Items
+func (m *Model) indexOf(id string) (int, bool) {
+	for i, item := range m.data.Items {
+		if item.ID == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// BulkComplete completes the selected tasks
+func (m *Model) BulkComplete() {
+	targets := m.beginBulk()
+	now := time.Now()
+	for _, item := range targets {
+		if i, ok := m.indexOf(item.ID); ok {
+			m.data.Complete(i, now)
+		}
+	}
+	m.endBulk("Completed", len(targets))
+}
+
+// BulkDrop deletes the selected tasks
+func (m *Model) BulkDrop() {
+	targets := m.beginBulk()
+	for _, item := range targets {
+		if i, ok := m.indexOf(item.ID); ok {
+			m.data.Items = append(m.data.Items[:i], m.data.Items[i+1:]...)
+			m.data.Unblock(item.ID)
+		}
+	}
+	m.endBulk("Dropped", len(targets))
+}
+
+// BulkBump moves the selected tasks to the top, keeping their order
+func (m *Model) BulkBump() {
+	targets := m.beginBulk()
+	var bumped []model.Todo
+	for _, item := range targets {
+		if i, ok := m.indexOf(item.ID); ok {
+			bumped = append(bumped, m.data.Items[i])
+			m.data.Items = append(m.data.Items[:i], m.data.Items[i+1:]...)
+		}
+	}
+	m.data.Items = append(bumped, m.data.Items...)
+	m.endBulk("Bumped", len(targets))
+	m.table.SetCursor(0)
+}
+
+// BulkEdit applies the change ModeBulk prompted for to the selected tasks
+func (m *Model) BulkEdit(action, input string) error {
+	change, verb, err := m.bulkChange(action, input)
+	if err != nil {
+		return err
+	}
+	targets := m.beginBulk()
+	for _, item := range targets {
+		if i, ok := m.indexOf(item.ID); ok {
+			change(&m.data.Items[i])
+		}
+	}
+	m.endBulk(verb, len(targets))
+	return nil
+}
+
+// bulkChange parses what was typed into the ModeBulk prompt into a change
+// to apply to each task, and the verb that describes it
+func (m *Model) bulkChange(action, input string) (func(*model.Todo), string, error) {
+	switch action {
+	case bulkTags:
+		add, remove := parseTagEdits(input)
+		if len(add) == 0 && len(remove) == 0 {
+			return nil, "", fmt.Errorf("no tags given")
+		}
+		return func(t *model.Todo) {
+			t.Tags = model.RemoveTags(model.AddTags(t.Tags, add...), remove...)
+		}, "Retagged", nil
+	case bulkPriority:
+		priority, err := model.ParsePriority(input)
+		if err != nil {
+			return nil, "", err
+		}
+		return func(t *model.Todo) { t.Priority = priority }, "Reprioritized", nil
+	case bulkMove:
+		context, err := m.resolveContext(input)
+		if err != nil {
+			return nil, "", err
+		}
+		return func(t *model.Todo) { t.SetContext(context) }, "Moved", nil
+	}
+	return nil, "", fmt.Errorf("unknown bulk change %q", action)
+}
+
+// parseTagEdits splits "#a b -c" into tags to add (a, b) and remove (c)
+func parseTagEdits(input string) (add, remove []string) {
+	for _, word := range strings.Fields(input) {
+		if tag, ok := strings.CutPrefix(word, "-"); ok {
+			remove = append(remove, tag)
+		} else {
+			add = append(add, strings.TrimPrefix(word, "+"))
+		}
+	}
+	return add, remove
+}
+
+// resolveContext turns what was typed into the move prompt into a context:
+// "global" (or nothing) for a global task, otherwise a directory relative
+// to the current one
+func (m *Model) resolveContext(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" || strings.EqualFold(input, "global") {
+		return "", nil
+	}
+	if strings.HasPrefix(input, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		input = filepath.Join(home, input[1:])
+	}
+	if !filepath.IsAbs(input) {
+		input = filepath.Join(m.cwd, input)
+	}
+	return filepath.Clean(input), nil
+}
+
+// Undo reverts the last bulk change
+func (m *Model) Undo() bool {
+	if m.undo == nil {
+		return false
+	}
+	m.data = m.undo
+	m.undo = nil
+	m.notice = "Undid: " + strings.ToLower(m.undoLabel[:1]) + m.undoLabel[1:]
+	m.ClearSelection()
+	m.refreshTable()
+	return true
+}
@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"upnext/internal/model"
+)
+
+func bulkTasks() []model.Todo {
+	return []model.Todo{
+		{ID: "a", Text: "one", Context: "/work"},
+		{ID: "b", Text: "two", Context: "/work", Tags: []string{"ops"}},
+		{ID: "c", Text: "three", Context: "/work"},
+		{ID: "d", Text: "four", Context: "/work"},
+	}
+}
+
+func TestSelection(t *testing.T) {
+	m, _ := newTestModel(t, bulkTasks()...)
+
+	m.ToggleSelect()
+	m.table.SetCursor(2)
+	m.ToggleSelect()
+	if got := ids(m.bulkTargets()); got != "ac" {
+		t.Errorf("targets = %q, want ac", got)
+	}
+	m.ToggleSelect()
+	if got := ids(m.bulkTargets()); got != "a" {
+		t.Errorf("after unselecting c, targets = %q, want a", got)
+	}
+
+	// A range counts while it's being made, and sticks once finished
+	m.table.SetCursor(1)
+	m.ToggleRange()
+	m.table.SetCursor(3)
+	if got := m.SelectionCount(); got != 4 {
+		t.Errorf("selection with a range open = %d, want 4", got)
+	}
+	m.ToggleRange()
+	m.table.SetCursor(0)
+	if got := ids(m.bulkTargets()); got != "abcd" {
+		t.Errorf("targets = %q, want abcd", got)
+	}
+
+	m.SelectAll()
+	if m.SelectionCount() != 0 {
+		t.Error("SelectAll with everything selected should clear the selection")
+	}
+	m.SelectAll()
+	if m.SelectionCount() != 4 {
+		t.Error("SelectAll should select every visible task")
+	}
+
+	// Nothing selected means the task under the cursor
+	m.ClearSelection()
+	m.table.SetCursor(1)
+	if got := ids(m.bulkTargets()); got != "b" {
+		t.Errorf("targets without a selection = %q, want b", got)
+	}
+}
+
+func TestBulkActionsUndo(t *testing.T) {
+	tests := []struct {
+		name  string
+		apply func(m *Model)
+		check func(t *testing.T, d *model.Data)
+	}{
+		{"complete", func(m *Model) { m.BulkComplete() }, func(t *testing.T, d *model.Data) {
+			if ids(d.Items) != "ad" || len(d.Archive) != 2 || d.Stats.TotalCompleted != 2 {
+				t.Errorf("items %q, %d archived", ids(d.Items), len(d.Archive))
+			}
+		}},
+		{"drop", func(m *Model) { m.BulkDrop() }, func(t *testing.T, d *model.Data) {
+			if ids(d.Items) != "ad" || len(d.Archive) != 0 {
+				t.Errorf("items %q, %d archived", ids(d.Items), len(d.Archive))
+			}
+		}},
+		{"bump", func(m *Model) { m.BulkBump() }, func(t *testing.T, d *model.Data) {
+			if ids(d.Items) != "bcad" {
+				t.Errorf("items %q, want bcad", ids(d.Items))
+			}
+		}},
+		{"tags", func(m *Model) { _ = m.BulkEdit(bulkTags, "+bug -ops") }, func(t *testing.T, d *model.Data) {
+			if !model.HasTag(d.Items[1].Tags, "bug") || model.HasTag(d.Items[1].Tags, "ops") || !model.HasTag(d.Items[2].Tags, "bug") {
+				t.Errorf("tags = %v, %v", d.Items[1].Tags, d.Items[2].Tags)
+			}
+		}},
+		{"priority", func(m *Model) { _ = m.BulkEdit(bulkPriority, "high") }, func(t *testing.T, d *model.Data) {
+			if d.Items[1].Priority != model.PriorityHigh || d.Items[2].Priority != model.PriorityHigh || d.Items[0].Priority == model.PriorityHigh {
+				t.Errorf("priorities = %v, %v, %v", d.Items[0].Priority, d.Items[1].Priority, d.Items[2].Priority)
+			}
+		}},
+		{"move", func(m *Model) { _ = m.BulkEdit(bulkMove, "api") }, func(t *testing.T, d *model.Data) {
+			if d.Items[1].Context != "/work/api" || d.Items[2].Context != "/work/api" {
+				t.Errorf("contexts = %q, %q", d.Items[1].Context, d.Items[2].Context)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestModel(t, bulkTasks()...)
+			before := m.data.Clone()
+			m.table.SetCursor(1)
+			m.ToggleRange()
+			m.table.SetCursor(2)
+			m.ToggleRange()
+
+			tt.apply(&m)
+			tt.check(t, m.data)
+			if m.SelectionCount() != 0 || m.notice == "" {
+				t.Errorf("selection %d, notice %q; want it cleared and described", m.SelectionCount(), m.notice)
+			}
+
+			if !m.Undo() {
+				t.Fatal("nothing to undo")
+			}
+			if ids(m.data.Items) != ids(before.Items) || len(m.data.Archive) != 0 || m.data.Items[1].Context != "/work" ||
+				!model.HasTag(m.data.Items[1].Tags, "ops") || m.data.Stats != before.Stats {
+				t.Errorf("undo left items %+v", m.data.Items)
+			}
+			if m.Undo() {
+				t.Error("undid twice")
+			}
+		})
+	}
+}
+
+func TestUndoAfterWake(t *testing.T) {
+	later := time.Now().Add(time.Hour)
+	m, s := newTestModel(t, append(bulkTasks(), model.Todo{ID: "e", Text: "snoozed", Context: "/work", SnoozedUntil: &later})...)
+	m.BulkDrop()
+
+	// The hour passes
+	past := time.Now().Add(-time.Minute)
+	for _, d := range []*model.Data{m.data, m.undo} {
+		d.Items[len(d.Items)-1].SnoozedUntil = &past
+	}
+
+	m = update(t, m, wakeTickMsg{})
+	if got := ids(s.data.Items); got != "ebcd" {
+		t.Fatalf("after waking, saved %q, want e on top", got)
+	}
+	if !m.Undo() {
+		t.Fatal("waking a snoozed task lost the bulk change's undo")
+	}
+	if got := ids(m.data.Items); got != "eabcd" || m.data.Items[0].SnoozedUntil != nil {
+		t.Errorf("after undoing, items %q with e snoozed until %v; want eabcd, e still awake", got, m.data.Items[0].SnoozedUntil)
+	}
+}
+
+func TestBulkChangeErrors(t *testing.T) {
+	m, _ := newTestModel(t, bulkTasks()...)
+	for _, tt := range []struct{ action, input string }{
+		{bulkTags, "  "},
+		{bulkPriority, "urgent"},
+		{"rename", "x"},
+	} {
+		if err := m.BulkEdit(tt.action, tt.input); err == nil {
+			t.Errorf("BulkEdit(%q, %q) succeeded", tt.action, tt.input)
+		}
+	}
+	if m.undo != nil {
+		t.Error("a failed bulk change left something to undo")
+	}
+}
+
+func TestParseTagEdits(t *testing.T) {
+	add, remove := parseTagEdits("#a +b -c d")
+	if len(add) != 3 || add[0] != "#a" || add[1] != "b" || add[2] != "d" || len(remove) != 1 || remove[0] != "c" {
+		t.Errorf("parseTagEdits() = %v, %v", add, remove)
+	}
+}
@@ -7,26 +7,45 @@ import (
 
 // KeyMap defines the key bindings using bubbles key.Binding
 type KeyMap struct {
-	Up         key.Binding
-	Down       key.Binding
-	PageUp     key.Binding
-	PageDown   key.Binding
-	GotoTop    key.Binding
-	GotoBottom key.Binding
-	Done       key.Binding
-	Add        key.Binding
-	Drop       key.Binding
-	Bump       key.Binding
-	Help       key.Binding
-	Quit       key.Binding
-	Confirm    key.Binding
-	Cancel     key.Binding
-	Tab        key.Binding // Switch between Active/Completed tabs
-	FormTab    key.Binding // Tab between form fields
-	Left       key.Binding
-	Right      key.Binding
-	ToggleAll  key.Binding // Toggle show all tasks
-	Uncomplete key.Binding // Move completed task back to active
+	Up           key.Binding
+	Down         key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	GotoTop      key.Binding
+	GotoBottom   key.Binding
+	Done         key.Binding
+	Add          key.Binding
+	Drop         key.Binding
+	Bump         key.Binding
+	MoveUp       key.Binding // Move the task up one row
+	MoveDown     key.Binding // Move the task down one row
+	Grab         key.Binding // Pick up the task to move it further
+	Help         key.Binding
+	Quit         key.Binding
+	Confirm      key.Binding
+	Cancel       key.Binding
+	Tab          key.Binding // Switch between Active/Completed/Snoozed tabs
+	FormTab      key.Binding // Tab between form fields
+	Left         key.Binding
+	Right        key.Binding
+	ToggleAll    key.Binding // Toggle show all tasks
+	Sort         key.Binding // Cycle active list sort order
+	TagFilter    key.Binding // Pick a tag to filter by
+	Snooze       key.Binding // Snooze (Active tab) or wake (Snoozed tab) a task
+	Steps        key.Binding // Expand the selected task's checklist
+	ToggleStep   key.Binding // Tick or untick the selected step
+	Focus        key.Binding // Show only the next task
+	Skip         key.Binding // Move on to the next task in focus mode
+	Timer        key.Binding // Start or stop a work timer on the selected task
+	Select       key.Binding // Add the task to the selection for bulk actions
+	SelectRange  key.Binding // Start or finish selecting a range of tasks
+	SelectAll    key.Binding // Select every visible task
+	Retag        key.Binding // Add or remove tags on the selected tasks
+	SetPriority  key.Binding // Change the priority of the selected tasks
+	Move         key.Binding // Move the selected tasks to another context
+	Undo         key.Binding // Revert the last bulk change
+	Uncomplete   key.Binding // Move completed task back to active
+	ClearArchive key.Binding // Delete the completed tasks shown
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -71,6 +90,18 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("b"),
 		key.WithHelp("b", "bump"),
 	),
+	MoveUp: key.NewBinding(
+		key.WithKeys("K", "shift+up"),
+		key.WithHelp("K", "move up"),
+	),
+	MoveDown: key.NewBinding(
+		key.WithKeys("J", "shift+down"),
+		key.WithHelp("J", "move down"),
+	),
+	Grab: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "grab"),
+	),
 	Help: key.NewBinding(
 		key.WithKeys("?"),
 		key.WithHelp("?", "help"),
@@ -88,8 +119,8 @@ var DefaultKeyMap = KeyMap{
 		key.WithHelp("esc", "cancel"),
 	),
 	Tab: key.NewBinding(
-		key.WithKeys("1", "2"),
-		key.WithHelp("1/2", "switch tab"),
+		key.WithKeys("1", "2", "3"),
+		key.WithHelp("1/2/3", "switch tab"),
 	),
 	FormTab: key.NewBinding(
 		key.WithKeys("tab", "shift+tab"),
@@ -107,10 +138,74 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("A"),
 		key.WithHelp("A", "toggle all"),
 	),
+	Sort: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "sort by due"),
+	),
+	Snooze: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "snooze"),
+	),
+	Steps: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "steps"),
+	),
+	ToggleStep: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "toggle step"),
+	),
+	Focus: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "focus"),
+	),
+	Skip: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "skip"),
+	),
+	Timer: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "timer"),
+	),
+	Select: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "select"),
+	),
+	SelectRange: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "select range"),
+	),
+	SelectAll: key.NewBinding(
+		key.WithKeys("*"),
+		key.WithHelp("*", "select all"),
+	),
+	Retag: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "retag"),
+	),
+	SetPriority: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "priority"),
+	),
+	Move: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "move"),
+	),
+	Undo: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "undo"),
+	),
+	TagFilter: key.NewBinding(
+		key.WithKeys("#"),
+		key.WithHelp("#", "filter by tag"),
+	),
 	Uncomplete: key.NewBinding(
 		key.WithKeys("u"),
 		key.WithHelp("u", "uncomplete"),
 	),
+	ClearArchive: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "clear completed"),
+	),
 }
 
 // matchesKey checks if a key message matches a key binding
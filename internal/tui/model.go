@@ -3,6 +3,8 @@ package tui
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -12,6 +14,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"upnext/internal/config"
 	"upnext/internal/model"
 	"upnext/internal/store"
 	"upnext/internal/ui"
@@ -26,6 +29,20 @@ const (
 	ModeHelp
 	ModeCelebration
 	ModeConfirm
+	ModeTagPicker
+	ModeSnooze
+	ModeSteps // Checklist of the selected task is expanded
+	ModeBulk  // Prompting for a change to the selected tasks
+	ModeGrab  // Moving a task up and down the list
+)
+
+// Actions that ModeConfirm asks about
+const (
+	confirmComplete     = "complete"      // Complete a task with unfinished steps
+	confirmBulkComplete = "bulk-complete" // Complete the selected tasks
+	confirmDrop         = "drop"          // Drop a task, or delete it from the archive
+	confirmBulkDrop     = "bulk-drop"     // Drop the selected tasks
+	confirmClearArchive = "clear-archive" // Delete the completed tasks shown
 )
 
 // Tab represents which tab is active
@@ -34,35 +51,100 @@ type Tab int
 const (
 	TabActive Tab = iota
 	TabCompleted
+	TabSnoozed
+	tabCount
+)
+
+// SortMode controls the order of the active list
+type SortMode int
+
+const (
+	SortManual SortMode = iota // List order, as arranged with bump and moves
+	SortDue                    // Earliest due date first, undated tasks last
+)
+
+// Input form fields, in tab order
+const (
+	fieldTitle = iota
+	fieldDesc
+	fieldDue
+	fieldEstimate
+	fieldPriority
+	fieldCount
 )
 
 // Model is the main Bubble Tea model
 type Model struct {
-	data           *model.Data
-	store          store.Store
-	table          table.Model
-	help           help.Model
-	keys           KeyMap
-	width          int
-	height         int
-	mode           Mode
-	tab            Tab
-	titleInput     textinput.Model
-	descInput      textinput.Model
-	priorityIndex  int
-	inputFocus     int // 0 = title, 1 = description, 2 = priority
-	celebrationMsg string
-	confirmAction  string
-	err            error
-	cwd            string // Current working directory for context filtering
-	showAllTasks   bool   // If true, show all tasks regardless of context
-	filteredItems  []model.Todo
+	data            *model.Data
+	store           store.Store
+	sources         store.Sourced // The store when it combines several files, for the Source column
+	table           table.Model
+	help            help.Model
+	keys            KeyMap
+	width           int
+	height          int
+	mode            Mode
+	tab             Tab
+	titleInput      textinput.Model
+	descInput       textinput.Model
+	dueInput        textinput.Model
+	estimateInput   textinput.Model
+	snoozeInput     textinput.Model
+	priorityIndex   int
+	prioritySet     bool // The priority was picked in the form, so quick-add tokens don't override it
+	inputFocus      int  // One of the field* constants
+	celebrationMsg  string
+	tableStyles     table.Styles // Also used by renderTable, which lays out the rows itself
+	confirmAction   string       // What ModeConfirm is asking about, one of the confirm* constants
+	stepCursor      int          // Selected step in ModeSteps
+	focused         bool         // Focus mode: only the next task is shown
+	focusIndex      int          // Task shown in focus mode, an index into filteredItems
+	timer           *Timer       // Running work timer, nil when there is none
+	timerSerial     int          // Numbers the timers started, see timerTickMsg
+	ringBell        bool         // View rings the terminal bell, after a timer phase ends
+	timerConfig     config.Timer
+	confirmEnabled  bool            // Ask before destructive actions, off with [tui] confirm = false
+	selected        map[string]bool // IDs of active tasks picked for bulk actions
+	rangeAnchor     int             // Row where a range selection started, -1 when none
+	bulkAction      string          // What ModeBulk asks for, one of the bulk* constants
+	bulkInput       textinput.Model
+	undo            *model.Data  // State before the last bulk change, nil when there is none
+	undoLabel       string       // Describes the change undo would revert
+	notice          string       // One-off message in the status bar, cleared on the next key
+	grabOrder       []model.Todo // Order of m.data.Items before the grab, to cancel it
+	grabCursor      int          // Row the grabbed task was picked up from
+	err             error
+	cwd             string         // Current working directory for context filtering
+	checkout        model.Checkout // Git checkout cwd is in, for tasks anchored to its repository
+	branch          string         // Git branch checked out in cwd, "" when none
+	showAllTasks    bool           // If true, show all tasks regardless of context
+	sortMode        SortMode
+	tagFilter       string   // Only show tasks with this tag ("" = all)
+	tagOptions      []string // Tags offered by the tag picker
+	tagCursor       int      // Selected row in the tag picker
+	filteredItems   []model.Todo
+	filteredSnoozed []model.Todo // Snoozed tasks in the current context, soonest first
 	filteredArchive []model.ArchivedTodo
+	coldArchive     []model.ArchivedTodo // Completed tasks in cold storage, loaded with the Completed tab
+	coldLoaded      bool
+	coldDeleted     []model.ArchivedTodo // Tasks taken out of cold storage, deleted from it on the next save
 }
 
 // celebrationTickMsg is sent to end the celebration animation
 type celebrationTickMsg struct{}
 
+// wakeTickMsg is sent periodically to bring back snoozed tasks
+type wakeTickMsg struct{}
+
+// wakeInterval is how often snoozed tasks are checked
+const wakeInterval = 30 * time.Second
+
+func wakeTick() tea.Cmd {
+	return tea.Tick(wakeInterval, func(time.Time) tea.Msg {
+		return wakeTickMsg{}
+	})
+}
+
 // NewWithContext creates a new TUI model with context awareness
 func NewWithContext(s store.Store, cwd string, showAll bool) (Model, error) {
 	data, err := s.Load()
@@ -70,16 +152,17 @@ func NewWithContext(s store.Store, cwd string, showAll bool) (Model, error) {
 		return Model{}, err
 	}
 
-	// Create table with expanded columns for fuller view
-	columns := []table.Column{
-		{Title: "", Width: 3},             // Status icon
-		{Title: "Pri", Width: 5},          // Priority
-		{Title: "Task", Width: 30},        // Task text
-		{Title: "Description", Width: 20}, // Description preview
-		{Title: "Context", Width: 15},     // Context/path
-		{Title: "Age", Width: 10},         // Age
+	// Bring back anything whose snooze ran out while we weren't looking
+	if data.WakeSnoozed(time.Now()) {
+		if err := s.Save(data); err != nil {
+			return Model{}, err
+		}
 	}
 
+	// Create table with expanded columns for fuller view
+	sources, _ := s.(store.Sourced)
+	columns := tableColumns(80, TabActive, sources != nil)
+
 	t := table.New(
 		table.WithColumns(columns),
 		table.WithFocused(true),
@@ -137,7 +220,7 @@ func NewWithContext(s store.Store, cwd string, showAll bool) (Model, error) {
 	// Create inputs
 	titleInput := textinput.New()
 	titleInput.Placeholder = "What needs to be done?"
-	titleInput.CharLimit = 100
+	titleInput.CharLimit = 200
 	titleInput.Width = 40
 	titleInput.PromptStyle = ui.FocusedStyle
 	titleInput.TextStyle = lipgloss.NewStyle().Foreground(ui.Text)
@@ -149,21 +232,65 @@ func NewWithContext(s store.Store, cwd string, showAll bool) (Model, error) {
 	descInput.PromptStyle = ui.BlurredStyle
 	descInput.TextStyle = lipgloss.NewStyle().Foreground(ui.Text)
 
+	dueInput := textinput.New()
+	dueInput.Placeholder = "Optional due date: fri, in 3d, eom..."
+	dueInput.CharLimit = 30
+	dueInput.Width = 40
+	dueInput.PromptStyle = ui.BlurredStyle
+	dueInput.TextStyle = lipgloss.NewStyle().Foreground(ui.Text)
+
+	estimateInput := textinput.New()
+	estimateInput.Placeholder = "Optional estimate: 30m, 1h30m, 3pt..."
+	estimateInput.CharLimit = 20
+	estimateInput.Width = 40
+	estimateInput.PromptStyle = ui.BlurredStyle
+	estimateInput.TextStyle = lipgloss.NewStyle().Foreground(ui.Text)
+
+	snoozeInput := textinput.New()
+	snoozeInput.Placeholder = "2h, tomorrow, mon, in 3d..."
+	snoozeInput.CharLimit = 30
+	snoozeInput.Width = 30
+	snoozeInput.PromptStyle = ui.FocusedStyle
+	snoozeInput.TextStyle = lipgloss.NewStyle().Foreground(ui.Text)
+
+	// A broken config file shouldn't keep the list from opening; Load falls
+	// back to the defaults and the error shows in the status bar
+	cfg, cfgErr := config.Load()
+
+	bulkInput := textinput.New()
+	bulkInput.CharLimit = 200
+	bulkInput.Width = 40
+	bulkInput.PromptStyle = ui.FocusedStyle
+	bulkInput.TextStyle = lipgloss.NewStyle().Foreground(ui.Text)
+
 	m := Model{
-		data:          data,
-		store:         s,
-		table:         t,
-		help:          h,
-		keys:          DefaultKeyMap,
-		width:         80,
-		height:        24,
-		mode:          ModeNormal,
-		tab:           TabActive,
-		titleInput:    titleInput,
-		descInput:     descInput,
-		priorityIndex: 1, // Default to Medium
-		cwd:           cwd,
-		showAllTasks:  showAll,
+		data:           data,
+		store:          s,
+		sources:        sources,
+		table:          t,
+		help:           h,
+		keys:           DefaultKeyMap,
+		width:          80,
+		height:         24,
+		mode:           ModeNormal,
+		tab:            TabActive,
+		titleInput:     titleInput,
+		descInput:      descInput,
+		dueInput:       dueInput,
+		estimateInput:  estimateInput,
+		snoozeInput:    snoozeInput,
+		bulkInput:      bulkInput,
+		selected:       map[string]bool{},
+		rangeAnchor:    -1,
+		tableStyles:    s2,
+		priorityIndex:  1, // Default to Medium
+		timerConfig:    cfg.Timer,
+		confirmEnabled: cfg.TUI.Confirm,
+		cwd:            cwd,
+		checkout:       store.CheckoutOf(cwd),
+		branch:         model.CurrentBranch(cwd),
+		showAllTasks:   showAll,
+		err:            cfgErr,
 	}
 
 	m.refreshFiltered()
@@ -177,15 +304,139 @@ func New(s store.Store) (Model, error) {
 	return NewWithContext(s, cwd, false)
 }
 
+// columnLayout holds the widths of the flexible table columns
+type columnLayout struct {
+	task, tags, desc, ctx int
+}
+
+// columnWidths splits the available width between the flexible columns
+func columnWidths(width int) columnLayout {
+	// Reserve space: status(3) + pri(5) + due(10) + est(7) + age(10) + padding(~21) = 56
+	availableWidth := width - 56
+	if availableWidth < 60 {
+		availableWidth = 60
+	}
+	return columnLayout{
+		task: max(availableWidth*38/100, 15),
+		tags: max(availableWidth*14/100, 6),
+		desc: max(availableWidth*26/100, 10),
+		ctx:  max(availableWidth*22/100, 10),
+	}
+}
+
+// sourceWidth is the width of the Source column
+const sourceWidth = 10
+
+// tableColumns returns the table columns sized for the given width, with a
+// Source column at the end when tasks come from more than one file
+func tableColumns(width int, tab Tab, sources bool) []table.Column {
+	if sources {
+		width -= sourceWidth
+	}
+	w := columnWidths(width)
+	lastTitle := "Age"
+	if tab == TabSnoozed {
+		lastTitle = "Wakes"
+	}
+	columns := []table.Column{
+		{Title: "", Width: 3},                 // Status icon
+		{Title: "Pri", Width: 5},              // Priority
+		{Title: "Task", Width: w.task},        // Task text
+		{Title: "Tags", Width: w.tags},        // Tags
+		{Title: "Description", Width: w.desc}, // Description preview
+		{Title: "Context", Width: w.ctx},      // Context/path
+		{Title: "Due", Width: 10},             // Due date
+		{Title: "Est", Width: 7},              // Estimate
+		{Title: lastTitle, Width: 10},         // Age, or wake time when snoozed
+	}
+	if sources {
+		columns = append(columns, table.Column{Title: "Source", Width: sourceWidth})
+	}
+	return columns
+}
+
 // refreshFiltered updates the filtered items based on context
 func (m *Model) refreshFiltered() {
+	archive := m.data.Archive
+	if len(m.coldArchive) > 0 {
+		archive = model.MergeArchive(m.coldArchive, m.data.Archive)
+	}
 	if m.showAllTasks || m.cwd == "" {
 		m.filteredItems = m.data.Items
-		m.filteredArchive = m.data.Archive
+		m.filteredArchive = archive
 	} else {
-		m.filteredItems = m.data.FilterByContext(m.cwd)
-		m.filteredArchive = m.data.FilterArchiveByContext(m.cwd)
+		m.filteredItems = nil
+		branches := model.Branches{}
+		for _, item := range m.data.FilterByContext(m.cwd, m.checkout) {
+			// Tasks for another branch wait until it is checked out
+			if branches.Visible(item, m.checkout) {
+				m.filteredItems = append(m.filteredItems, item)
+			}
+		}
+		m.filteredArchive = nil
+		for _, item := range archive {
+			if model.IsContextRelevant(item.ContextIn(m.checkout), m.cwd) {
+				m.filteredArchive = append(m.filteredArchive, item)
+			}
+		}
+	}
+
+	// Tasks scheduled for later stay out of the active list until then,
+	// and snoozed tasks move to their own tab
+	now := time.Now()
+	visible := make([]model.Todo, 0, len(m.filteredItems))
+	m.filteredSnoozed = nil
+	for _, item := range m.filteredItems {
+		switch {
+		case item.IsSnoozed(now):
+			m.filteredSnoozed = append(m.filteredSnoozed, item)
+		case !item.IsScheduledLater(now):
+			visible = append(visible, item)
+		}
+	}
+	m.filteredItems = visible
+	sort.SliceStable(m.filteredSnoozed, func(i, j int) bool {
+		return m.filteredSnoozed[i].SnoozedUntil.Before(*m.filteredSnoozed[j].SnoozedUntil)
+	})
+
+	if m.tagFilter != "" {
+		var items []model.Todo
+		for _, item := range m.filteredItems {
+			if model.HasTag(item.Tags, m.tagFilter) {
+				items = append(items, item)
+			}
+		}
+		m.filteredItems = items
+
+		var snoozed []model.Todo
+		for _, item := range m.filteredSnoozed {
+			if model.HasTag(item.Tags, m.tagFilter) {
+				snoozed = append(snoozed, item)
+			}
+		}
+		m.filteredSnoozed = snoozed
+
+		var archive []model.ArchivedTodo
+		for _, item := range m.filteredArchive {
+			if model.HasTag(item.Tags, m.tagFilter) {
+				archive = append(archive, item)
+			}
+		}
+		m.filteredArchive = archive
+	}
+
+	if m.sortMode == SortDue {
+		sort.SliceStable(m.filteredItems, func(i, j int) bool {
+			a, b := m.filteredItems[i].Due, m.filteredItems[j].Due
+			if a == nil || b == nil {
+				return a != nil
+			}
+			return a.Before(*b)
+		})
 	}
+
+	// Tasks waiting on others go below the ones that can be done now
+	m.filteredItems = m.data.SortBlockedLast(m.filteredItems)
 }
 
 // refreshTable updates the table rows from the data
@@ -193,17 +444,12 @@ func (m *Model) refreshTable() {
 	m.refreshFiltered()
 
 	// Calculate column widths dynamically
-	taskWidth := 28
-	descWidth := 18
-	ctxWidth := 13
-	if m.width > 0 {
-		availableWidth := m.width - 35 // Reserve for status, pri, age, padding
-		if availableWidth > 60 {
-			taskWidth = availableWidth * 45 / 100
-			descWidth = availableWidth * 30 / 100
-			ctxWidth = availableWidth * 25 / 100
-		}
+	width := m.width
+	if m.sources != nil {
+		width -= sourceWidth
 	}
+	w := columnWidths(width)
+	now := time.Now()
 
 	if m.tab == TabActive {
 		rows := make([]table.Row, len(m.filteredItems))
@@ -212,15 +458,60 @@ func (m *Model) refreshTable() {
 			if desc == "" {
 				desc = "-"
 			}
-			ctx := model.GetContextDisplay(item.Context, m.cwd)
+			ctx := model.GetContextDisplay(item.ContextIn(m.checkout), m.cwd)
+			if m.data.IsBlocked(item) {
+				due := "-"
+				if item.Due != nil {
+					due = formatDue(*item.Due, now)
+				}
+				rows[i] = table.Row{
+					ui.IconBlocked,
+					ui.DimStyle.Render(priorityGlyph(item.Priority)),
+					ui.DimStyle.Render(taskCell(item, w.task)),
+					ui.DimStyle.Render(truncateText(formatTags(item.Tags), w.tags)),
+					ui.DimStyle.Render(truncateText(desc, w.desc)),
+					ui.DimStyle.Render(truncateText(ctx, w.ctx)),
+					ui.DimStyle.Render(due),
+					ui.DimStyle.Render(estimateCell(item.Estimate)),
+					ui.DimStyle.Render(formatAge(item.Created)),
+				}
+				rows[i] = m.withSource(rows[i], item.ID, item.Context)
+				continue
+			}
 			rows[i] = table.Row{
 				ui.IconUnchecked,
 				m.priorityIcon(item.Priority),
-				truncateText(item.Text, taskWidth),
-				truncateText(desc, descWidth),
-				truncateText(ctx, ctxWidth),
+				taskCell(item, w.task),
+				truncateText(formatTags(item.Tags), w.tags),
+				truncateText(desc, w.desc),
+				truncateText(ctx, w.ctx),
+				m.dueCell(item, now),
+				estimateCell(item.Estimate),
 				formatAge(item.Created),
 			}
+			rows[i] = m.withSource(rows[i], item.ID, item.Context)
+		}
+		m.table.SetRows(rows)
+	} else if m.tab == TabSnoozed {
+		rows := make([]table.Row, len(m.filteredSnoozed))
+		for i, item := range m.filteredSnoozed {
+			desc := item.Description
+			if desc == "" {
+				desc = "-"
+			}
+			ctx := model.GetContextDisplay(item.ContextIn(m.checkout), m.cwd)
+			rows[i] = table.Row{
+				ui.IconSnoozed,
+				m.priorityIcon(item.Priority),
+				taskCell(item, w.task),
+				truncateText(formatTags(item.Tags), w.tags),
+				truncateText(desc, w.desc),
+				truncateText(ctx, w.ctx),
+				m.dueCell(item, now),
+				estimateCell(item.Estimate),
+				formatUntil(*item.SnoozedUntil),
+			}
+			rows[i] = m.withSource(rows[i], item.ID, item.Context)
 		}
 		m.table.SetRows(rows)
 	} else {
@@ -233,29 +524,90 @@ func (m *Model) refreshTable() {
 			if desc == "" {
 				desc = "-"
 			}
-			ctx := model.GetContextDisplay(item.Context, m.cwd)
+			ctx := model.GetContextDisplay(item.ContextIn(m.checkout), m.cwd)
 			rows[i] = table.Row{
 				ui.IconChecked,
 				m.priorityIcon(item.Priority),
-				truncateText(item.Text, taskWidth),
-				truncateText(desc, descWidth),
-				truncateText(ctx, ctxWidth),
+				truncateText(item.Text, w.task),
+				truncateText(formatTags(item.Tags), w.tags),
+				truncateText(desc, w.desc),
+				truncateText(ctx, w.ctx),
+				formatDate(item.Due),
+				estimateCell(item.Estimate),
 				formatAge(item.Completed),
 			}
+			rows[i] = m.withSource(rows[i], item.ID, item.Context)
 		}
 		m.table.SetRows(rows)
 	}
 }
 
+// withSource adds the Source cell to a row when the store combines files
+func (m *Model) withSource(row table.Row, id, context string) table.Row {
+	if m.sources == nil {
+		return row
+	}
+	return append(row, ui.DimStyle.Render(m.sources.Source(id, context)))
+}
+
 func (m *Model) priorityIcon(p model.Priority) string {
 	switch p {
 	case model.PriorityHigh:
-		return ui.PriorityHighStyle.Render(ui.IconHigh)
+		return ui.PriorityHighStyle.Render(priorityGlyph(p))
+	case model.PriorityMedium:
+		return ui.PriorityMediumStyle.Render(priorityGlyph(p))
+	default:
+		return ui.PriorityLowStyle.Render(priorityGlyph(p))
+	}
+}
+
+// priorityGlyph returns the unstyled priority icon
+func priorityGlyph(p model.Priority) string {
+	switch p {
+	case model.PriorityHigh:
+		return ui.IconHigh
 	case model.PriorityMedium:
-		return ui.PriorityMediumStyle.Render(ui.IconMedium)
+		return ui.IconMedium
+	default:
+		return ui.IconLow
+	}
+}
+
+// dueCell renders the Due column, highlighting overdue and due-today tasks
+func (m *Model) dueCell(item model.Todo, now time.Time) string {
+	if item.Due == nil {
+		return "-"
+	}
+	switch {
+	case item.IsOverdue(now):
+		return ui.OverdueStyle.Render(formatDue(*item.Due, now))
+	case item.IsDueToday(now):
+		return ui.DueTodayStyle.Render(formatDue(*item.Due, now))
 	default:
-		return ui.PriorityLowStyle.Render(ui.IconLow)
+		return formatDue(*item.Due, now)
+	}
+}
+
+// formatTags renders tags for a table cell, or "-" when there are none
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	return "#" + strings.Join(tags, " #")
+}
+
+// taskCell renders the task column: the text, marked when it repeats, and
+// checklist progress, which is kept visible when the text is truncated
+func taskCell(item model.Todo, width int) string {
+	text := item.Text
+	if item.Repeat != "" {
+		text = ui.IconRepeat + " " + text
 	}
+	progress := model.StepSummary(item.Steps)
+	if progress == "" {
+		return truncateText(text, width)
+	}
+	return truncateText(text, width-len(progress)-1) + " " + progress
 }
 
 func truncateText(s string, max int) string {
@@ -281,26 +633,71 @@ func formatAge(t time.Time) string {
 	}
 }
 
+// formatUntil is the counterpart of formatAge for times in the future
+func formatUntil(t time.Time) string {
+	d := time.Until(t)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("in %dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("in %dh", int(d.Hours()))
+	case d < 7*24*time.Hour:
+		return fmt.Sprintf("in %dd", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("in %dw", int(d.Hours()/(24*7)))
+	}
+}
+
+// formatDue describes a due day relative to today
+func formatDue(due, now time.Time) string {
+	days := int(model.StartOfDay(due).Sub(model.StartOfDay(now)).Hours() / 24)
+	switch {
+	case days < 0:
+		return fmt.Sprintf("%dd late", -days)
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days < 14:
+		return fmt.Sprintf("in %dd", days)
+	default:
+		return fmt.Sprintf("in %dw", days/7)
+	}
+}
+
+// formatDate renders an optional date as "Jan 2", or "-" when unset
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("Jan 2")
+}
+
+// estimateCell shows a task's estimate, or "-" without one
+func estimateCell(estimate string) string {
+	if estimate == "" {
+		return "-"
+	}
+	return estimate
+}
+
 // Init implements tea.Model
 func (m Model) Init() tea.Cmd {
-	return nil
+	return wakeTick()
 }
 
-// AddTodo adds a new todo item with context
-func (m *Model) AddTodo(text, description string, priority model.Priority) {
-	if text == "" {
+// AddTodo adds a new todo item at the top of the list. ID, Created and
+// Position are filled in; Context is kept as given.
+func (m *Model) AddTodo(todo model.Todo) {
+	if todo.Text == "" {
 		return
 	}
 
-	todo := model.Todo{
-		ID:          model.GenerateID(),
-		Text:        text,
-		Description: description,
-		Priority:    priority,
-		Created:     time.Now(),
-		Position:    0,
-		Context:     m.cwd, // Set context to current working directory
-	}
+	todo.ID = model.GenerateID()
+	todo.Created = time.Now()
+	todo.Position = 0
 
 	// Shift all positions down
 	for i := range m.data.Items {
@@ -327,30 +724,14 @@ func (m *Model) CompleteTodo() bool {
 	// Get the actual item from filtered list
 	item := m.filteredItems[cursor]
 
-	// Find and remove from actual data.Items
+	// Archive the actual item; repeating tasks are replaced by their next instance
 	for i, dataItem := range m.data.Items {
 		if dataItem.ID == item.ID {
-			// Add to archive with all fields preserved
-			archived := model.ArchivedTodo{
-				ID:          item.ID,
-				Text:        item.Text,
-				Description: item.Description,
-				Priority:    item.Priority,
-				Created:     item.Created,
-				Completed:   time.Now(),
-				Context:     item.Context,
-			}
-			m.data.Archive = append(m.data.Archive, archived)
-
-			// Remove from items
-			m.data.Items = append(m.data.Items[:i], m.data.Items[i+1:]...)
+			m.data.Complete(i, time.Now())
 			break
 		}
 	}
 
-	// Update stats
-	m.data.Stats.TotalCompleted++
-
 	m.refreshTable()
 	return true
 }
@@ -369,53 +750,71 @@ func (m *Model) UncompleteTodo() bool {
 	// Get the actual item from filtered list (reversed)
 	item := m.filteredArchive[len(m.filteredArchive)-1-cursor]
 
-	// Find and remove from actual data.Archive
-	for i, archiveItem := range m.data.Archive {
-		if archiveItem.ID == item.ID {
-			// Create active todo from archived
-			todo := model.Todo{
-				ID:          item.ID,
-				Text:        item.Text,
-				Description: item.Description,
-				Priority:    item.Priority,
-				Created:     item.Created,
-				Position:    0,
-				Context:     item.Context,
-			}
+	// Remove it from the archive and make it active again
+	if m.removeArchived(item) > 0 {
+		m.data.Reopen(item)
+	}
 
-			// Shift all positions down
-			for j := range m.data.Items {
-				m.data.Items[j].Position++
-			}
+	m.refreshTable()
+	return true
+}
 
-			// Insert at the beginning
-			m.data.Items = append([]model.Todo{todo}, m.data.Items...)
+// removeArchived takes completed tasks out of the archive, and out of cold
+// storage when they have been moved there, returning how many it found
+func (m *Model) removeArchived(items ...model.ArchivedTodo) int {
+	remove := make(map[string]bool, len(items))
+	for _, item := range items {
+		remove[item.ID] = true
+	}
 
-			// Remove from archive
-			m.data.Archive = append(m.data.Archive[:i], m.data.Archive[i+1:]...)
-			break
+	found := map[string]bool{}
+	for _, item := range m.data.TakeArchived(func(a model.ArchivedTodo) bool { return remove[a.ID] }) {
+		found[item.ID] = true
+	}
+	kept := m.coldArchive[:0]
+	for _, item := range m.coldArchive {
+		if remove[item.ID] {
+			m.coldDeleted = append(m.coldDeleted, item)
+			found[item.ID] = true
+		} else {
+			kept = append(kept, item)
 		}
 	}
+	m.coldArchive = kept
+	return len(found)
+}
 
-	m.refreshTable()
-	return true
+// loadCold reads the completed tasks in cold storage the first time
+// they're needed, if the store keeps any
+func (m *Model) loadCold() {
+	cs, ok := m.store.(store.ColdStore)
+	if m.coldLoaded || !ok {
+		return
+	}
+	items, err := cs.LoadCold()
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.coldArchive = items
+	m.coldLoaded = true
 }
 
 // DropTodo removes the current todo without archiving
 func (m *Model) DropTodo() {
-	if m.tab == TabActive {
-		cursor := m.table.Cursor()
-		if len(m.filteredItems) == 0 || cursor >= len(m.filteredItems) {
+	if m.tab != TabCompleted {
+		item, ok := m.selectedTodo()
+		if !ok {
 			return
 		}
 
-		item := m.filteredItems[cursor]
 		for i, dataItem := range m.data.Items {
 			if dataItem.ID == item.ID {
 				m.data.Items = append(m.data.Items[:i], m.data.Items[i+1:]...)
 				break
 			}
 		}
+		m.data.Unblock(item.ID)
 	} else {
 		// Drop from completed (permanently delete)
 		cursor := m.table.Cursor()
@@ -423,17 +822,19 @@ func (m *Model) DropTodo() {
 			return
 		}
 
-		item := m.filteredArchive[len(m.filteredArchive)-1-cursor]
-		for i, archiveItem := range m.data.Archive {
-			if archiveItem.ID == item.ID {
-				m.data.Archive = append(m.data.Archive[:i], m.data.Archive[i+1:]...)
-				break
-			}
-		}
+		m.removeArchived(m.filteredArchive[len(m.filteredArchive)-1-cursor])
 	}
 	m.refreshTable()
 }
 
+// ClearArchive permanently deletes the completed tasks shown on the
+// Completed tab
+func (m *Model) ClearArchive() {
+	m.removeArchived(m.filteredArchive...)
+	m.refreshTable()
+	m.table.SetCursor(0)
+}
+
 // BumpTodo moves the current todo to the top
 func (m *Model) BumpTodo() {
 	if m.tab != TabActive {
@@ -460,13 +861,223 @@ func (m *Model) BumpTodo() {
 	m.table.SetCursor(0)
 }
 
-// SwitchTab switches between Active and Completed tabs
+// SwitchTab cycles through the Active, Completed and Snoozed tabs
 func (m *Model) SwitchTab() {
-	if m.tab == TabActive {
-		m.tab = TabCompleted
+	m.SetTab((m.tab + 1) % tabCount)
+}
+
+// SetTab shows the given tab
+func (m *Model) SetTab(tab Tab) {
+	if m.tab == tab {
+		return
+	}
+	m.tab = tab
+	if tab == TabCompleted {
+		m.loadCold()
+	}
+	m.ClearSelection()
+	m.table.SetColumns(tableColumns(m.width, tab, m.sources != nil))
+	m.refreshTable()
+	m.table.SetCursor(0)
+}
+
+// selectedTodo returns the task under the cursor on the Active or Snoozed tab
+func (m *Model) selectedTodo() (model.Todo, bool) {
+	items := m.filteredItems
+	if m.tab == TabSnoozed {
+		items = m.filteredSnoozed
+	} else if m.tab != TabActive {
+		return model.Todo{}, false
+	}
+
+	cursor := m.table.Cursor()
+	if len(items) == 0 || cursor >= len(items) {
+		return model.Todo{}, false
+	}
+	return items[cursor], true
+}
+
+// selectedArchived returns the completed task under the cursor
+func (m *Model) selectedArchived() (model.ArchivedTodo, bool) {
+	cursor := m.table.Cursor()
+	if m.tab != TabCompleted || cursor >= len(m.filteredArchive) {
+		return model.ArchivedTodo{}, false
+	}
+	return m.filteredArchive[len(m.filteredArchive)-1-cursor], true
+}
+
+// SnoozeTodo hides the selected active task until the given time
+func (m *Model) SnoozeTodo(until time.Time) {
+	if m.tab != TabActive {
+		return
+	}
+	item, ok := m.selectedTodo()
+	if !ok {
+		return
+	}
+	for i := range m.data.Items {
+		if m.data.Items[i].ID == item.ID {
+			m.data.Items[i].SnoozedUntil = &until
+			break
+		}
+	}
+	m.refreshTable()
+}
+
+// WakeTodo brings the selected snoozed task back to the top of the list
+func (m *Model) WakeTodo() {
+	if m.tab != TabSnoozed {
+		return
+	}
+	item, ok := m.selectedTodo()
+	if !ok {
+		return
+	}
+	for i := range m.data.Items {
+		if m.data.Items[i].ID == item.ID {
+			now := time.Now()
+			m.data.Items[i].SnoozedUntil = &now
+			break
+		}
+	}
+	m.data.WakeSnoozed(time.Now())
+	m.refreshTable()
+}
+
+// OpenSteps expands the checklist of the selected active task
+func (m *Model) OpenSteps() {
+	if m.tab != TabActive {
+		return
+	}
+	if item, ok := m.selectedTodo(); ok && len(item.Steps) > 0 {
+		m.mode = ModeSteps
+		m.stepCursor = 0
+	}
+}
+
+// ToggleStep ticks or unticks the step under the step cursor
+func (m *Model) ToggleStep() bool {
+	item, ok := m.selectedTodo()
+	if !ok {
+		return false
+	}
+	for i := range m.data.Items {
+		if m.data.Items[i].ID == item.ID {
+			if err := m.data.Items[i].ToggleStep(m.stepCursor); err != nil {
+				return false
+			}
+			m.refreshTable()
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleTimer starts a work timer on the selected task, or stops the
+// running one. Only one timer runs at a time; starting one on another task
+// replaces it.
+func (m *Model) ToggleTimer(now time.Time) tea.Cmd {
+	item, ok := m.selectedTodo()
+	if m.focused {
+		item, _, ok = m.focusedTodo()
+	}
+	if m.timer != nil && (!ok || m.timer.TaskID == item.ID) {
+		m.timer = nil
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	m.timerSerial++
+	m.timer = NewTimer(item, m.timerConfig, now)
+	m.timer.serial = m.timerSerial
+	return m.timer.tick()
+}
+
+// AdvanceTimer moves the running timer on, recording the session on its
+// task when the work part ends, and clears it once it's finished
+func (m *Model) AdvanceTimer(now time.Time) timerEvent {
+	event := m.timer.Advance(now)
+	if event == timerWorkDone {
+		recordPomodoro(m.data, m.timer.TaskID, now)
+		m.refreshTable()
+	}
+	if m.timer.Finished(now) {
+		m.timer = nil
+	}
+	return event
+}
+
+// EnterFocus switches to focus mode on the top active task
+func (m *Model) EnterFocus() {
+	m.SetTab(TabActive)
+	m.focused = true
+	m.focusIndex = 0
+}
+
+// ExitFocus returns to the table with the focused task selected
+func (m *Model) ExitFocus() {
+	m.focused = false
+	if _, i, ok := m.focusedTodo(); ok {
+		m.table.SetCursor(i)
+	}
+}
+
+// SkipFocus moves focus to the next task without reordering, wrapping
+// around at the end of the list
+func (m *Model) SkipFocus() {
+	if n := len(m.filteredItems); n > 0 {
+		m.focusIndex = (m.focusIndex + 1) % n
+	}
+}
+
+// focusedTodo returns the task shown in focus mode and its index in
+// filteredItems. Once the list shrinks past focusIndex, focus wraps to the
+// top.
+func (m *Model) focusedTodo() (model.Todo, int, bool) {
+	if len(m.filteredItems) == 0 {
+		return model.Todo{}, 0, false
+	}
+	i := m.focusIndex
+	if i >= len(m.filteredItems) {
+		i = 0
+	}
+	return m.filteredItems[i], i, true
+}
+
+// CycleSort switches the active list between manual and due-date order
+func (m *Model) CycleSort() {
+	if m.sortMode == SortManual {
+		m.sortMode = SortDue
 	} else {
-		m.tab = TabActive
+		m.sortMode = SortManual
+	}
+	m.refreshTable()
+	m.table.SetCursor(0)
+}
+
+// OpenTagPicker lists the tags in the current context for filtering
+func (m *Model) OpenTagPicker() {
+	scoped := *m.data
+	scoped.Items = m.data.FilterByContext(m.cwd, m.checkout)
+	if m.showAllTasks || m.cwd == "" {
+		scoped.Items = m.data.Items
+	}
+	m.tagOptions = scoped.AllTags()
+
+	// Start on the current filter; row 0 is "all tasks"
+	m.tagCursor = 0
+	for i, tag := range m.tagOptions {
+		if tag == m.tagFilter {
+			m.tagCursor = i + 1
+		}
 	}
+	m.mode = ModeTagPicker
+}
+
+// SetTagFilter limits both tabs to tasks with tag ("" clears the filter)
+func (m *Model) SetTagFilter(tag string) {
+	m.tagFilter = tag
 	m.refreshTable()
 	m.table.SetCursor(0)
 }
@@ -477,9 +1088,47 @@ func (m *Model) ToggleShowAll() {
 	m.refreshTable()
 }
 
-// Save persists the current state
+// Save persists a change the user made. Undo only reaches back over bulk
+// changes, so once anything else is saved the last one can't be undone.
 func (m *Model) Save() error {
-	return m.store.Save(m.data)
+	m.undo = nil
+	return m.persist()
+}
+
+// saveBulk persists a bulk change, keeping the state before it for Undo
+func (m *Model) saveBulk() error {
+	return m.persist()
+}
+
+// persist writes the data, then deletes the completed tasks taken out of
+// cold storage since the last save
+func (m *Model) persist() error {
+	var before []model.ArchivedTodo
+	if m.coldLoaded {
+		before = append(before, m.data.Archive...)
+	}
+	if err := m.store.Save(m.data); err != nil {
+		return err
+	}
+	if len(m.data.Archive) < len(before) {
+		// Saving moved old tasks to cold storage; keep showing them
+		kept := make(map[string]bool, len(m.data.Archive))
+		for _, item := range m.data.Archive {
+			kept[item.ID] = true
+		}
+		for _, item := range before {
+			if !kept[item.ID] {
+				m.coldArchive = append(m.coldArchive, item)
+			}
+		}
+	}
+	if cs, ok := m.store.(store.ColdStore); ok && len(m.coldDeleted) > 0 {
+		if err := cs.DeleteCold(m.coldDeleted); err != nil {
+			return err
+		}
+	}
+	m.coldDeleted = nil
+	return nil
 }
 
 // IsCelebrationMilestone checks if we hit a celebration milestone
@@ -489,8 +1138,11 @@ func (m *Model) IsCelebrationMilestone() bool {
 
 // GetCurrentItems returns the currently displayed items based on tab
 func (m *Model) GetCurrentItems() int {
-	if m.tab == TabActive {
+	switch m.tab {
+	case TabActive:
 		return len(m.filteredItems)
+	case TabSnoozed:
+		return len(m.filteredSnoozed)
 	}
 	return len(m.filteredArchive)
 }
@@ -513,6 +1165,19 @@ func RunWithContext(s store.Store, cwd string, showAll bool) error {
 	return err
 }
 
+// RunFocus starts the TUI in focus mode, showing only the next task
+func RunFocus(s store.Store, cwd string, showAll bool) error {
+	m, err := NewWithContext(s, cwd, showAll)
+	if err != nil {
+		return err
+	}
+	m.EnterFocus()
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	_, err = p.Run()
+	return err
+}
+
 // ShortHelp returns keybindings to be shown in the mini help view
 func (k KeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{k.Up, k.Down, k.Done, k.Add, k.Tab, k.Help, k.Quit}
@@ -522,8 +1187,10 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.PageUp, k.PageDown},
-		{k.GotoTop, k.GotoBottom, k.Tab, k.ToggleAll},
-		{k.Done, k.Add, k.Drop, k.Bump},
+		{k.GotoTop, k.GotoBottom, k.Tab, k.ToggleAll, k.Sort, k.TagFilter},
+		{k.Done, k.Add, k.Drop, k.Bump, k.MoveUp, k.MoveDown, k.Grab},
+		{k.Snooze, k.Steps, k.Focus, k.Timer, k.ClearArchive},
+		{k.Select, k.SelectRange, k.SelectAll, k.Retag, k.SetPriority, k.Move, k.Undo},
 		{k.Help, k.Quit},
 	}
 }
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"upnext/internal/model"
+)
+
+// memStore keeps the data in memory, remembering what was last saved
+type memStore struct {
+	data  *model.Data
+	saves int
+}
+
+func (s *memStore) Load() (*model.Data, error) { return s.data, nil }
+
+func (s *memStore) Save(data *model.Data) error {
+	s.data = data
+	s.saves++
+	return nil
+}
+
+// newTestModel starts the TUI in /work on the given tasks, with the default
+// config and a window large enough to show them all
+func newTestModel(t *testing.T, items ...model.Todo) (Model, *memStore) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	data := model.NewData()
+	data.Items = items
+	s := &memStore{data: data}
+	m, err := NewWithContext(s, "/work", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40}), s
+}
+
+// update feeds msg to m, returning the updated model
+func update(t *testing.T, m Model, msg tea.Msg) Model {
+	t.Helper()
+	next, _ := m.Update(msg)
+	return next.(Model)
+}
+
+// press sends each key in turn, as typed
+func press(t *testing.T, m Model, keys ...string) Model {
+	t.Helper()
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case " ":
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		m = update(t, m, msg)
+	}
+	return m
+}
+
+// ids lists the IDs of todos in order
+func ids(todos []model.Todo) string {
+	var s string
+	for _, todo := range todos {
+		s += todo.ID
+	}
+	return s
+}
+
+func TestSwitchTab(t *testing.T) {
+	m, _ := newTestModel(t)
+	for _, want := range []Tab{TabCompleted, TabSnoozed, TabActive} {
+		m.SwitchTab()
+		if m.tab != want {
+			t.Fatalf("SwitchTab() went to tab %d, want %d", m.tab, want)
+		}
+	}
+}
+
+// coldMemStore is a memStore with cold storage
+type coldMemStore struct {
+	memStore
+	cold    []model.ArchivedTodo
+	deleted []string
+}
+
+func (s *coldMemStore) LoadCold() ([]model.ArchivedTodo, error) { return s.cold, nil }
+
+func (s *coldMemStore) SaveCold(items []model.ArchivedTodo) error {
+	s.cold = append(s.cold, items...)
+	return nil
+}
+
+func (s *coldMemStore) DeleteCold(items []model.ArchivedTodo) error {
+	for _, item := range items {
+		s.deleted = append(s.deleted, item.ID)
+	}
+	return nil
+}
+
+func TestClearArchive(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	data := model.NewData()
+	data.Archive = []model.ArchivedTodo{
+		{ID: "a", Context: "/work"},
+		{ID: "b", Context: "/elsewhere"},
+	}
+	s := &coldMemStore{memStore: memStore{data: data}, cold: []model.ArchivedTodo{
+		{ID: "c", Context: "/work"},
+		{ID: "d", Context: "/elsewhere"},
+	}}
+	m, err := NewWithContext(s, "/work", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m = press(t, m, "2", "C", "y")
+	if len(s.data.Archive) != 1 || s.data.Archive[0].ID != "b" {
+		t.Errorf("archive = %+v, want only b from the other context", s.data.Archive)
+	}
+	if len(s.deleted) != 1 || s.deleted[0] != "c" {
+		t.Errorf("deleted %v from cold storage, want c", s.deleted)
+	}
+	if len(m.coldArchive) != 1 || m.coldArchive[0].ID != "d" {
+		t.Errorf("cold archive = %+v, want only d", m.coldArchive)
+	}
+}
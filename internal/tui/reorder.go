@@ -0,0 +1,75 @@
+package tui
+
+import "upnext/internal/model"
+
+// MoveTodo moves the task under the cursor one row up (delta -1) or down
+// (delta 1) in the list as shown, which may skip over tasks hidden by the
+// context or tag filter. It only works in manual order, and blocked tasks
+// stay below the ones that can be done now.
+func (m *Model) MoveTodo(delta int) bool {
+	if m.tab != TabActive || !m.canReorder() {
+		return false
+	}
+	cursor := m.table.Cursor()
+	target := cursor + delta
+	if cursor >= len(m.filteredItems) || target < 0 || target >= len(m.filteredItems) {
+		return false
+	}
+
+	item, other := m.filteredItems[cursor], m.filteredItems[target]
+	if m.data.IsBlocked(item) != m.data.IsBlocked(other) {
+		return false
+	}
+	from, ok := m.indexOf(item.ID)
+	if !ok {
+		return false
+	}
+	to, ok := m.indexOf(other.ID)
+	if !ok {
+		return false
+	}
+
+	// Going past the neighbour, not to its index, keeps the move right
+	// when tasks in between are filtered out
+	m.data.MoveTodo(from, to, delta > 0)
+	m.refreshTable()
+	m.table.SetCursor(target)
+	return true
+}
+
+// canReorder reports whether tasks can be moved, saying why not in the
+// status bar when they can't
+func (m *Model) canReorder() bool {
+	if m.sortMode != SortManual {
+		m.notice = "Sorted by due date · o for manual order to move tasks"
+		return false
+	}
+	return true
+}
+
+// Grab picks up the task under the cursor so it can be moved several rows
+// before being dropped
+func (m *Model) Grab() bool {
+	if _, ok := m.selectedTodo(); !ok || m.tab != TabActive || !m.canReorder() {
+		return false
+	}
+	m.grabOrder = append([]model.Todo(nil), m.data.Items...)
+	m.grabCursor = m.table.Cursor()
+	m.mode = ModeGrab
+	return true
+}
+
+// DropGrabbed leaves the grabbed task where it is now
+func (m *Model) DropGrabbed() {
+	m.grabOrder = nil
+	m.mode = ModeNormal
+}
+
+// CancelGrab puts the grabbed task back where it was picked up
+func (m *Model) CancelGrab() {
+	m.data.Items = m.grabOrder
+	m.grabOrder = nil
+	m.mode = ModeNormal
+	m.refreshTable()
+	m.table.SetCursor(m.grabCursor)
+}
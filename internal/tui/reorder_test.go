@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"testing"
+
+	"upnext/internal/model"
+)
+
+func reorderTasks() []model.Todo {
+	return []model.Todo{
+		{ID: "a", Context: "/work"},
+		{ID: "b", Context: "/elsewhere"},
+		{ID: "c", Context: "/work"},
+		{ID: "d", Context: "/work"},
+		{ID: "e", Context: "/elsewhere"},
+		{ID: "f", Context: "/work", BlockedBy: []string{"a"}},
+	}
+}
+
+func TestMoveTodo(t *testing.T) {
+	m, s := newTestModel(t, reorderTasks()...)
+
+	// Shown as a c d f; b and e are in another context
+	m = press(t, m, "J")
+	if got := ids(m.data.Items); got != "bcadef" {
+		t.Errorf("after moving a down, items = %q, want bcadef", got)
+	}
+	if m.table.Cursor() != 1 {
+		t.Errorf("cursor = %d, want it to follow the task to 1", m.table.Cursor())
+	}
+
+	m = press(t, m, "J")
+	if got := ids(m.data.Items); got != "bcdaef" {
+		t.Errorf("after moving a down again, items = %q, want bcdaef", got)
+	}
+
+	// Blocked tasks stay below the ones that can be done now
+	if m.MoveTodo(1) {
+		t.Errorf("moved a below blocked f: %q", ids(m.data.Items))
+	}
+
+	m = press(t, m, "K", "K")
+	if got := ids(m.data.Items); got != "bacdef" {
+		t.Errorf("after moving a back up, items = %q, want bacdef", got)
+	}
+	if m.MoveTodo(-1) {
+		t.Error("moved the top task up")
+	}
+	if s.saves == 0 {
+		t.Error("moves weren't saved")
+	}
+}
+
+func TestMoveTodoSorted(t *testing.T) {
+	m, _ := newTestModel(t, reorderTasks()...)
+	m.sortMode = SortDue
+	if m.MoveTodo(1) || m.Grab() {
+		t.Error("moved a task while sorted by due date")
+	}
+	if m.notice == "" {
+		t.Error("no notice saying why the task didn't move")
+	}
+}
+
+func TestGrab(t *testing.T) {
+	m, s := newTestModel(t, reorderTasks()...)
+
+	m = press(t, m, "j", "r")
+	if m.mode != ModeGrab {
+		t.Fatalf("mode = %d, want ModeGrab", m.mode)
+	}
+	m = press(t, m, "j", "j")
+	if got := ids(m.data.Items); got != "abdcef" {
+		t.Errorf("while grabbed, items = %q, want abdcef", got)
+	}
+	saves := s.saves
+	m = press(t, m, "esc")
+	if got := ids(m.data.Items); m.mode != ModeNormal || got != "abcdef" || m.table.Cursor() != 1 {
+		t.Errorf("after cancelling, mode %d, items %q, cursor %d; want the task back at 1", m.mode, got, m.table.Cursor())
+	}
+	if s.saves != saves {
+		t.Error("cancelling saved")
+	}
+
+	m = press(t, m, "r", "g", "enter")
+	if got := ids(m.data.Items); m.mode != ModeNormal || got != "cabdef" {
+		t.Errorf("after dropping at the top, mode %d, items %q; want cabdef", m.mode, got)
+	}
+	if ids(s.data.Items) != "cabdef" {
+		t.Errorf("saved %q, want cabdef", ids(s.data.Items))
+	}
+
+	m = press(t, m, "r", "G", "r")
+	if got := ids(m.data.Items); got != "abdcef" {
+		t.Errorf("after dropping at the bottom, items %q; want abdcef, above the blocked task", got)
+	}
+}
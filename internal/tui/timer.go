@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"upnext/internal/config"
+	"upnext/internal/model"
+	"upnext/internal/store"
+	"upnext/internal/ui"
+)
+
+// timerPhase is the part of a work session the timer is in
+type timerPhase int
+
+const (
+	phaseWork timerPhase = iota
+	phaseBreak
+)
+
+// timerEvent is what happened when the timer advanced
+type timerEvent int
+
+const (
+	timerRunning   timerEvent = iota
+	timerWorkDone             // The work session ended; the break begins if there is one
+	timerBreakDone            // The break ended
+)
+
+// timerTickMsg is sent every second while a timer runs. The serial ties it
+// to one timer so ticks from a cancelled timer are ignored.
+type timerTickMsg struct{ serial int }
+
+// Timer counts down a work session on a task, followed by a break
+type Timer struct {
+	TaskID   string
+	TaskText string
+	phase    timerPhase
+	started  time.Time // Start of the current phase
+	work     time.Duration
+	rest     time.Duration
+	serial   int
+}
+
+// NewTimer starts a work session on todo with the configured lengths
+func NewTimer(todo model.Todo, cfg config.Timer, now time.Time) *Timer {
+	return &Timer{
+		TaskID:   todo.ID,
+		TaskText: todo.Text,
+		phase:    phaseWork,
+		started:  now,
+		work:     cfg.Work,
+		rest:     cfg.Break,
+	}
+}
+
+// tick schedules the next timerTickMsg
+func (t *Timer) tick() tea.Cmd {
+	serial := t.serial
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return timerTickMsg{serial: serial}
+	})
+}
+
+// length returns how long the current phase lasts
+func (t *Timer) length() time.Duration {
+	if t.phase == phaseBreak {
+		return t.rest
+	}
+	return t.work
+}
+
+// Finished reports whether nothing is left to count down
+func (t *Timer) Finished(now time.Time) bool {
+	return t.phase == phaseBreak && t.Remaining(now) == 0
+}
+
+// Remaining returns the time left in the current phase
+func (t *Timer) Remaining(now time.Time) time.Duration {
+	return max(t.length()-now.Sub(t.started), 0)
+}
+
+// Advance moves the timer on to now, starting the break once the work
+// session is over
+func (t *Timer) Advance(now time.Time) timerEvent {
+	if t.Remaining(now) > 0 {
+		return timerRunning
+	}
+	if t.phase == phaseWork {
+		t.phase = phaseBreak
+		t.started = now
+		return timerWorkDone
+	}
+	return timerBreakDone
+}
+
+// View renders the countdown on one line
+func (t *Timer) View(width int, now time.Time) string {
+	label := "🍅 " + t.TaskText
+	if t.phase == phaseBreak {
+		label = "☕ Break"
+	}
+	remaining := t.Remaining(now)
+	clock := fmt.Sprintf("%02d:%02d", int(remaining.Minutes()), int(remaining.Seconds())%60)
+
+	barWidth := max(min(width/3, 40), 10)
+	elapsed := 1.0
+	if t.length() > 0 {
+		elapsed -= float64(remaining) / float64(t.length())
+	}
+	label = truncateText(label, max(width-barWidth-12, 10))
+
+	return lipgloss.JoinHorizontal(lipgloss.Center,
+		ui.TitleStyle.Render(label),
+		"  ",
+		ui.RenderProgressBar(elapsed, barWidth),
+		"  ",
+		ui.SubtitleStyle.Render(clock),
+	)
+}
+
+// recordPomodoro notes a finished work session on the timer's task
+func recordPomodoro(data *model.Data, taskID string, at time.Time) {
+	for i := range data.Items {
+		if data.Items[i].ID == taskID {
+			data.Items[i].Pomodoros = append(data.Items[i].Pomodoros, at)
+			return
+		}
+	}
+}
+
+// bellMsg asks for the terminal bell. The bell goes out with the next
+// view, as writing to the terminal directly would race the renderer.
+type bellMsg struct{}
+
+func bell() tea.Msg {
+	return bellMsg{}
+}
+
+// withBell adds the bell character to a view when ring is set
+func withBell(view string, ring bool) string {
+	if ring {
+		return view + "\a"
+	}
+	return view
+}
+
+// timerModel runs a single timer on its own, for `upnext timer start`
+type timerModel struct {
+	timer *Timer
+	store store.Store
+	width int
+	err   error
+	ring  bool // Ring the bell with the next view
+}
+
+func (m timerModel) Init() tea.Cmd {
+	return m.timer.tick()
+}
+
+func (m timerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.ring = false
+	switch msg := msg.(type) {
+	case bellMsg:
+		m.ring = true
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+
+	case timerTickMsg:
+		now := time.Now()
+		switch m.timer.Advance(now) {
+		case timerWorkDone:
+			m.err = m.record(now)
+			if m.timer.Finished(now) {
+				return m, tea.Sequence(bell, tea.Quit)
+			}
+			return m, tea.Batch(bell, m.timer.tick())
+		case timerBreakDone:
+			return m, tea.Sequence(bell, tea.Quit)
+		}
+		return m, m.timer.tick()
+	}
+	return m, nil
+}
+
+// record saves the finished work session to the store
+func (m timerModel) record(at time.Time) error {
+	data, err := m.store.Load()
+	if err != nil {
+		return err
+	}
+	recordPomodoro(data, m.timer.TaskID, at)
+	return m.store.Save(data)
+}
+
+func (m timerModel) View() string {
+	view := m.timer.View(max(m.width, 60), time.Now()) + "\n" + ui.DimStyle.Render("q: stop")
+	if m.err != nil {
+		view += "\n" + ui.ErrorStyle.Render(m.err.Error())
+	}
+	return withBell(view+"\n", m.ring)
+}
+
+// RunTimer counts down a work session on the task with the given ID in
+// the terminal, records it when it ends, and then counts down the break
+func RunTimer(s store.Store, cfg config.Timer, taskID string) error {
+	data, err := s.Load()
+	if err != nil {
+		return err
+	}
+	i, err := data.FindTodo(taskID)
+	if err != nil {
+		return err
+	}
+
+	m := timerModel{timer: NewTimer(data.Items[i], cfg, time.Now()), store: s}
+	final, err := tea.NewProgram(m).Run()
+	if err != nil {
+		return err
+	}
+	return final.(timerModel).err
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"upnext/internal/config"
+	"upnext/internal/model"
+)
+
+func TestTimerPhases(t *testing.T) {
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	timer := NewTimer(model.Todo{ID: "a", Text: "write"}, config.Timer{Work: 25 * time.Minute, Break: 5 * time.Minute}, start)
+
+	steps := []struct {
+		after     time.Duration
+		event     timerEvent
+		phase     timerPhase
+		remaining time.Duration
+		finished  bool
+	}{
+		{10 * time.Minute, timerRunning, phaseWork, 15 * time.Minute, false},
+		{25 * time.Minute, timerWorkDone, phaseBreak, 5 * time.Minute, false},
+		{28 * time.Minute, timerRunning, phaseBreak, 2 * time.Minute, false},
+		{31 * time.Minute, timerBreakDone, phaseBreak, 0, true},
+	}
+	for _, step := range steps {
+		now := start.Add(step.after)
+		if event := timer.Advance(now); event != step.event {
+			t.Errorf("after %s: Advance() = %d, want %d", step.after, event, step.event)
+		}
+		if timer.phase != step.phase || timer.Remaining(now) != step.remaining || timer.Finished(now) != step.finished {
+			t.Errorf("after %s: phase %d, %s left, finished %v; want phase %d, %s left, finished %v",
+				step.after, timer.phase, timer.Remaining(now), timer.Finished(now), step.phase, step.remaining, step.finished)
+		}
+	}
+}
+
+func TestTimerWithoutBreak(t *testing.T) {
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	timer := NewTimer(model.Todo{ID: "a"}, config.Timer{Work: 25 * time.Minute}, start)
+	end := start.Add(25 * time.Minute)
+	if timer.Advance(end) != timerWorkDone || !timer.Finished(end) {
+		t.Error("a timer without a break should finish with the work session")
+	}
+}
+
+func TestModelTimer(t *testing.T) {
+	m, s := newTestModel(t, model.Todo{ID: "a", Text: "write", Context: "/work"})
+	start := time.Now()
+
+	m.ToggleTimer(start)
+	first := m.timer
+	if first == nil || first.TaskID != "a" {
+		t.Fatalf("timer = %+v, want one on a", first)
+	}
+
+	// Restarting numbers the new timer, so the old one's ticks are ignored
+	m.ToggleTimer(start)
+	m.ToggleTimer(start)
+	if m.timer == nil || m.timer.serial == first.serial {
+		t.Fatalf("restarted timer kept serial %d", first.serial)
+	}
+	m.timer.started = start.Add(-time.Hour)
+	m = update(t, m, timerTickMsg{serial: first.serial})
+	if m.timer.phase != phaseWork {
+		t.Error("a stale tick advanced the timer")
+	}
+
+	m = update(t, m, timerTickMsg{serial: m.timer.serial})
+	if m.timer == nil || m.timer.phase != phaseBreak {
+		t.Fatalf("timer = %+v, want it on its break", m.timer)
+	}
+	if got := len(s.data.Items[0].Pomodoros); got != 1 {
+		t.Errorf("recorded %d sessions, want 1", got)
+	}
+
+	m = update(t, m, bellMsg{})
+	if !strings.HasSuffix(m.View(), "\a") {
+		t.Error("the bell didn't go out with the view")
+	}
+	m = update(t, m, timerTickMsg{serial: -1})
+	if strings.HasSuffix(m.View(), "\a") {
+		t.Error("the bell rang twice")
+	}
+}
+
+func TestBrokenConfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "upnext"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "upnext", "config.toml"), []byte("[timer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	m, err := NewWithContext(&memStore{data: model.NewData()}, "/work", false)
+	if err != nil {
+		t.Fatalf("a broken config kept the list from opening: %v", err)
+	}
+	if m.err == nil || m.timerConfig != config.Default().Timer {
+		t.Errorf("err = %v, timer config = %+v; want the error and the defaults", m.err, m.timerConfig)
+	}
+	if !strings.Contains(m.renderStatusBar(), "config.toml") {
+		t.Error("the status bar doesn't show the config error")
+	}
+}
@@ -1,10 +1,12 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
-	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"upnext/internal/model"
@@ -13,7 +15,12 @@ import (
 
 // Update implements tea.Model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.ringBell = false
 	switch msg := msg.(type) {
+	case bellMsg:
+		m.ringBell = true
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -26,32 +33,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.table.SetHeight(tableHeight)
 
 		// Update column widths based on available space
-		// Reserve space: status(3) + pri(5) + age(10) + padding(~17) = 35
-		availableWidth := m.width - 35
-		if availableWidth < 60 {
-			availableWidth = 60
-		}
-		// Split available space between task, description, and context
-		taskWidth := availableWidth * 45 / 100
-		descWidth := availableWidth * 30 / 100
-		ctxWidth := availableWidth * 25 / 100
-		if taskWidth < 15 {
-			taskWidth = 15
-		}
-		if descWidth < 10 {
-			descWidth = 10
-		}
-		if ctxWidth < 10 {
-			ctxWidth = 10
-		}
-		m.table.SetColumns([]table.Column{
-			{Title: "", Width: 3},
-			{Title: "Pri", Width: 5},
-			{Title: "Task", Width: taskWidth},
-			{Title: "Description", Width: descWidth},
-			{Title: "Context", Width: ctxWidth},
-			{Title: "Age", Width: 10},
-		})
+		m.table.SetColumns(tableColumns(m.width, m.tab, m.sources != nil))
 		m.refreshTable()
 
 		// Update help width
@@ -64,6 +46,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.titleInput.Width = inputWidth
 		m.descInput.Width = inputWidth
+		m.dueInput.Width = inputWidth
+		m.estimateInput.Width = inputWidth
 
 		return m, nil
 
@@ -72,6 +56,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.celebrationMsg = ""
 		return m, nil
 
+	case wakeTickMsg:
+		now := time.Now()
+		if m.data.WakeSnoozed(now) {
+			// Not the user's doing, so the last bulk change can still be
+			// undone, without snoozing the tasks again
+			if m.undo != nil {
+				m.undo.WakeSnoozed(now)
+			}
+			m.refreshTable()
+			if err := m.persist(); err != nil {
+				m.err = err
+			}
+		}
+		return m, wakeTick()
+
+	case timerTickMsg:
+		// Ticks from a stopped or replaced timer just die out
+		if m.timer == nil || msg.serial != m.timer.serial {
+			return m, nil
+		}
+		switch m.AdvanceTimer(time.Now()) {
+		case timerRunning:
+			return m, m.timer.tick()
+		case timerWorkDone:
+			if err := m.Save(); err != nil {
+				m.err = err
+			}
+		}
+		// A session or break just ended
+		if m.timer == nil {
+			return m, bell
+		}
+		return m, tea.Batch(bell, m.timer.tick())
+
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
 	}
@@ -99,7 +117,7 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Handle help mode
 	if m.mode == ModeHelp {
-		if key.Matches(msg, m.keys.Help) || key.Matches(msg, m.keys.Quit) || msg.String() == "enter" {
+		if key.Matches(msg, m.keys.Help) || key.Matches(msg, m.keys.Quit) || key.Matches(msg, m.keys.Confirm) {
 			m.mode = ModeNormal
 			return m, nil
 		}
@@ -111,31 +129,118 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleInputKeyPress(msg)
 	}
 
+	// Handle tag picker
+	if m.mode == ModeTagPicker {
+		return m.handleTagPickerKeyPress(msg)
+	}
+
+	// Handle snooze prompt
+	if m.mode == ModeSnooze {
+		return m.handleSnoozeKeyPress(msg)
+	}
+
+	// Handle expanded checklist
+	if m.mode == ModeSteps {
+		return m.handleStepsKeyPress(msg)
+	}
+
+	// Handle bulk change prompt
+	if m.mode == ModeBulk {
+		return m.handleBulkKeyPress(msg)
+	}
+
+	// Handle a grabbed task
+	if m.mode == ModeGrab {
+		return m.handleGrabKeyPress(msg)
+	}
+
+	// Handle confirmation prompt
+	if m.mode == ModeConfirm {
+		return m.handleConfirmKeyPress(msg)
+	}
+
+	// Handle focus mode
+	if m.focused {
+		return m.handleFocusKeyPress(msg)
+	}
+
+	m.notice = ""
+	selecting := m.SelectionCount() > 0
+
 	// Normal mode key handling
 	switch {
+	case selecting && msg.String() == "esc":
+		m.ClearSelection()
+		return m, nil
+
 	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
 
-	case key.Matches(msg, m.keys.Tab):
-		// Switch between Active and Completed tabs
-		m.SwitchTab()
+	case key.Matches(msg, m.keys.Select):
+		m.ToggleSelect()
 		return m, nil
 
-	case msg.String() == "1":
-		// Go to Active tab
-		if m.tab != TabActive {
-			m.tab = TabActive
-			m.refreshTable()
-			m.table.SetCursor(0)
+	case key.Matches(msg, m.keys.SelectRange):
+		m.ToggleRange()
+		return m, nil
+
+	case key.Matches(msg, m.keys.SelectAll):
+		m.SelectAll()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Retag), key.Matches(msg, m.keys.SetPriority), key.Matches(msg, m.keys.Move):
+		if len(m.bulkTargets()) == 0 {
+			return m, nil
+		}
+		m.commitRange()
+		m.mode = ModeBulk
+		switch {
+		case key.Matches(msg, m.keys.Retag):
+			m.bulkAction = bulkTags
+			m.bulkInput.Placeholder = "#tag to add, -tag to remove"
+		case key.Matches(msg, m.keys.SetPriority):
+			m.bulkAction = bulkPriority
+			m.bulkInput.Placeholder = "high, medium or low"
+		default:
+			m.bulkAction = bulkMove
+			m.bulkInput.Placeholder = "directory, or global"
+		}
+		m.bulkInput.SetValue("")
+		return m, m.bulkInput.Focus()
+
+	case key.Matches(msg, m.keys.Undo):
+		if m.Undo() {
+			if err := m.Save(); err != nil {
+				m.err = err
+			}
 		}
 		return m, nil
 
-	case msg.String() == "2":
-		// Go to Completed tab
-		if m.tab != TabCompleted {
-			m.tab = TabCompleted
-			m.refreshTable()
-			m.table.SetCursor(0)
+	case key.Matches(msg, m.keys.Tab):
+		// Go straight to the numbered tab
+		switch msg.String() {
+		case "1":
+			m.SetTab(TabActive)
+		case "2":
+			m.SetTab(TabCompleted)
+		case "3":
+			m.SetTab(TabSnoozed)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Snooze):
+		switch m.tab {
+		case TabActive:
+			if _, ok := m.selectedTodo(); ok {
+				m.mode = ModeSnooze
+				m.snoozeInput.SetValue("")
+				return m, m.snoozeInput.Focus()
+			}
+		case TabSnoozed:
+			m.WakeTodo()
+			if err := m.Save(); err != nil {
+				m.err = err
+			}
 		}
 		return m, nil
 
@@ -143,6 +248,16 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.ToggleShowAll()
 		return m, nil
 
+	case key.Matches(msg, m.keys.Sort):
+		if m.tab == TabActive {
+			m.CycleSort()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.TagFilter):
+		m.OpenTagPicker()
+		return m, nil
+
 	case key.Matches(msg, m.keys.Uncomplete):
 		if m.UncompleteTodo() {
 			if err := m.Save(); err != nil {
@@ -151,21 +266,37 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.Done) && selecting:
+		m.commitRange()
+		return m.confirm(confirmBulkComplete)
+
 	case key.Matches(msg, m.keys.Done):
 		if m.tab == TabActive {
-			if m.CompleteTodo() {
-				if err := m.Save(); err != nil {
-					m.err = err
-				}
-				// Check for celebration milestone
-				if m.IsCelebrationMilestone() {
-					m.mode = ModeCelebration
-					m.celebrationMsg = getCelebrationMessage(m.data.Stats.TotalCompleted)
-					return m, tea.Tick(time.Second*3, func(time.Time) tea.Msg {
-						return celebrationTickMsg{}
-					})
-				}
+			// Make sure unfinished steps aren't skipped by accident
+			if item, ok := m.selectedTodo(); ok && item.HasOpenSteps() {
+				return m.confirm(confirmComplete)
 			}
+			return m.completeSelected()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.ClearArchive):
+		if m.tab == TabCompleted && len(m.filteredArchive) > 0 {
+			return m.confirm(confirmClearArchive)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Steps):
+		m.OpenSteps()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Focus):
+		m.EnterFocus()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Timer):
+		if m.tab == TabActive {
+			return m, m.ToggleTimer(time.Now())
 		}
 		return m, nil
 
@@ -173,24 +304,35 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Only allow adding tasks in Active tab
 		if m.tab == TabActive {
 			m.mode = ModeInput
-			m.inputFocus = 0
+			m.inputFocus = fieldTitle
 			m.priorityIndex = 1 // Default to Medium
+			m.prioritySet = false
 			m.titleInput.SetValue("")
 			m.descInput.SetValue("")
-			m.titleInput.Focus()
-			m.titleInput.PromptStyle = ui.FocusedStyle
-			m.descInput.PromptStyle = ui.BlurredStyle
+			m.dueInput.SetValue("")
+			m.estimateInput.SetValue("")
+			m.updateInputFocus()
 			return m, m.titleInput.Focus()
 		}
 		return m, nil
 
-	case key.Matches(msg, m.keys.Drop):
-		m.DropTodo()
-		if err := m.Save(); err != nil {
+	case key.Matches(msg, m.keys.Drop) && selecting:
+		m.commitRange()
+		return m.confirm(confirmBulkDrop)
+
+	case key.Matches(msg, m.keys.Bump) && selecting:
+		m.BulkBump()
+		if err := m.saveBulk(); err != nil {
 			m.err = err
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.Drop):
+		if m.GetCurrentItems() > 0 {
+			return m.confirm(confirmDrop)
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.Bump):
 		m.BumpTodo()
 		if err := m.Save(); err != nil {
@@ -198,6 +340,22 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.MoveUp), key.Matches(msg, m.keys.MoveDown):
+		delta := 1
+		if key.Matches(msg, m.keys.MoveUp) {
+			delta = -1
+		}
+		if m.MoveTodo(delta) {
+			if err := m.Save(); err != nil {
+				m.err = err
+			}
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Grab):
+		m.Grab()
+		return m, nil
+
 	case key.Matches(msg, m.keys.Help):
 		m.mode = ModeHelp
 		return m, nil
@@ -210,61 +368,319 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// completeSelected completes the selected task and celebrates milestones
+func (m Model) completeSelected() (tea.Model, tea.Cmd) {
+	if !m.CompleteTodo() {
+		return m, nil
+	}
+	if err := m.Save(); err != nil {
+		m.err = err
+	}
+	// Check for celebration milestone
+	if m.IsCelebrationMilestone() {
+		m.mode = ModeCelebration
+		m.celebrationMsg = getCelebrationMessage(m.data.Stats.TotalCompleted)
+		return m, tea.Tick(time.Second*3, func(time.Time) tea.Msg {
+			return celebrationTickMsg{}
+		})
+	}
+	return m, nil
+}
+
+// completeBulk completes the selected tasks in one save
+func (m Model) completeBulk() (tea.Model, tea.Cmd) {
+	before := m.data.Stats.TotalCompleted
+	m.BulkComplete()
+	if err := m.saveBulk(); err != nil {
+		m.err = err
+	}
+	// Celebrate if the batch passed a milestone
+	if after := m.data.Stats.TotalCompleted; after/10 > before/10 {
+		m.mode = ModeCelebration
+		m.celebrationMsg = getCelebrationMessage(after / 10 * 10)
+		return m, tea.Tick(time.Second*3, func(time.Time) tea.Msg {
+			return celebrationTickMsg{}
+		})
+	}
+	return m, nil
+}
+
+func (m Model) handleBulkKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.mode = ModeNormal
+		m.bulkInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Confirm):
+		if err := m.BulkEdit(m.bulkAction, m.bulkInput.Value()); err != nil {
+			// Leave the prompt open; the preview shows what's wrong
+			return m, nil
+		}
+		if err := m.saveBulk(); err != nil {
+			m.err = err
+		}
+		m.mode = ModeNormal
+		m.bulkInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.bulkInput, cmd = m.bulkInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleGrabKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.CancelGrab()
+
+	case key.Matches(msg, m.keys.Confirm), key.Matches(msg, m.keys.Grab):
+		m.DropGrabbed()
+		if err := m.Save(); err != nil {
+			m.err = err
+		}
+
+	case key.Matches(msg, m.keys.Up), key.Matches(msg, m.keys.MoveUp):
+		m.MoveTodo(-1)
+
+	case key.Matches(msg, m.keys.Down), key.Matches(msg, m.keys.MoveDown):
+		m.MoveTodo(1)
+
+	case key.Matches(msg, m.keys.GotoTop):
+		for m.MoveTodo(-1) {
+		}
+
+	case key.Matches(msg, m.keys.GotoBottom):
+		for m.MoveTodo(1) {
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleFocusKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "q", msg.String() == "ctrl+c":
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Focus), key.Matches(msg, m.keys.Cancel):
+		m.ExitFocus()
+
+	case key.Matches(msg, m.keys.Skip):
+		m.SkipFocus()
+
+	case key.Matches(msg, m.keys.Timer):
+		return m, m.ToggleTimer(time.Now())
+
+	case key.Matches(msg, m.keys.Done):
+		item, i, ok := m.focusedTodo()
+		if !ok {
+			return m, nil
+		}
+		// Completing goes through the table cursor, like in the list; the
+		// next task then slides into the focused slot
+		m.focusIndex = i
+		m.table.SetCursor(i)
+		if item.HasOpenSteps() {
+			return m.confirm(confirmComplete)
+		}
+		return m.completeSelected()
+
+	case key.Matches(msg, m.keys.Help):
+		m.mode = ModeHelp
+	}
+	return m, nil
+}
+
+// confirm asks about action in ModeConfirm, or goes ahead with it right
+// away when confirmations are turned off
+func (m Model) confirm(action string) (tea.Model, tea.Cmd) {
+	if !m.confirmEnabled {
+		return m.runConfirmed(action)
+	}
+	m.mode = ModeConfirm
+	m.confirmAction = action
+	return m, nil
+}
+
+// runConfirmed carries out an action that ModeConfirm asked about
+func (m Model) runConfirmed(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case confirmComplete:
+		return m.completeSelected()
+	case confirmBulkComplete:
+		return m.completeBulk()
+	case confirmDrop:
+		m.DropTodo()
+		if err := m.Save(); err != nil {
+			m.err = err
+		}
+	case confirmBulkDrop:
+		m.BulkDrop()
+		if err := m.saveBulk(); err != nil {
+			m.err = err
+		}
+	case confirmClearArchive:
+		m.ClearArchive()
+		if err := m.Save(); err != nil {
+			m.err = err
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleConfirmKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm), msg.String() == "y", msg.String() == "Y":
+		m.mode = ModeNormal
+		action := m.confirmAction
+		m.confirmAction = ""
+		return m.runConfirmed(action)
+	case key.Matches(msg, m.keys.Cancel), msg.String() == "n", msg.String() == "N", msg.String() == "q":
+		m.mode = ModeNormal
+		m.confirmAction = ""
+	}
+	return m, nil
+}
+
+func (m Model) handleStepsKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	item, ok := m.selectedTodo()
+	if !ok {
+		m.mode = ModeNormal
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Cancel), key.Matches(msg, m.keys.Steps):
+		m.mode = ModeNormal
+	case key.Matches(msg, m.keys.Up):
+		if m.stepCursor > 0 {
+			m.stepCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.stepCursor < len(item.Steps)-1 {
+			m.stepCursor++
+		}
+	case key.Matches(msg, m.keys.ToggleStep):
+		if m.ToggleStep() {
+			if err := m.Save(); err != nil {
+				m.err = err
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleTagPickerKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel), key.Matches(msg, m.keys.TagFilter):
+		m.mode = ModeNormal
+	case key.Matches(msg, m.keys.Up):
+		if m.tagCursor > 0 {
+			m.tagCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.tagCursor < len(m.tagOptions) {
+			m.tagCursor++
+		}
+	case key.Matches(msg, m.keys.Confirm):
+		tag := ""
+		if m.tagCursor > 0 {
+			tag = m.tagOptions[m.tagCursor-1]
+		}
+		m.SetTagFilter(tag)
+		m.mode = ModeNormal
+	}
+	return m, nil
+}
+
+func (m Model) handleSnoozeKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.mode = ModeNormal
+		m.snoozeInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Confirm):
+		until, err := model.ParseWhen(m.snoozeInput.Value(), time.Now())
+		if err != nil {
+			// Leave the prompt open; the preview shows what's wrong
+			return m, nil
+		}
+		m.SnoozeTodo(until)
+		if err := m.Save(); err != nil {
+			m.err = err
+		}
+		m.mode = ModeNormal
+		m.snoozeInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.snoozeInput, cmd = m.snoozeInput.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleInputKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, m.keys.Cancel):
 		m.mode = ModeNormal
-		m.titleInput.Blur()
-		m.descInput.Blur()
+		m.blurInputs()
 		return m, nil
 
-	case msg.String() == "enter":
-		if m.inputFocus < 2 {
+	case key.Matches(msg, m.keys.Confirm):
+		if m.inputFocus < fieldCount-1 {
 			// Move to next field
 			m.inputFocus++
 			m.updateInputFocus()
 			return m, nil
 		}
 		// Submit the form
-		text := m.titleInput.Value()
-		if text != "" {
-			priority := model.Priority(m.priorityIndex)
-			m.AddTodo(text, m.descInput.Value(), priority)
+		if strings.TrimSpace(m.titleInput.Value()) != "" {
+			todo, field, err := m.formTodo()
+			if err != nil {
+				// Keep the form open so the field can be fixed
+				m.inputFocus = field
+				m.updateInputFocus()
+				return m, nil
+			}
+			m.AddTodo(todo)
 			if err := m.Save(); err != nil {
 				m.err = err
 			}
 		}
 		m.mode = ModeNormal
-		m.titleInput.Blur()
-		m.descInput.Blur()
+		m.blurInputs()
 		return m, nil
 
 	case msg.String() == "tab" || msg.String() == "shift+tab":
 		if msg.String() == "shift+tab" {
 			m.inputFocus--
 			if m.inputFocus < 0 {
-				m.inputFocus = 2
+				m.inputFocus = fieldCount - 1
 			}
 		} else {
 			m.inputFocus++
-			if m.inputFocus > 2 {
-				m.inputFocus = 0
+			if m.inputFocus >= fieldCount {
+				m.inputFocus = fieldTitle
 			}
 		}
 		m.updateInputFocus()
 		return m, nil
 
-	case m.inputFocus == 2: // Priority selection
+	case m.inputFocus == fieldPriority: // Priority selection
 		if key.Matches(msg, m.keys.Left) || msg.String() == "h" {
 			m.priorityIndex--
 			if m.priorityIndex < 0 {
 				m.priorityIndex = 2
 			}
+			m.prioritySet = true
 		} else if key.Matches(msg, m.keys.Right) || msg.String() == "l" {
 			m.priorityIndex++
 			if m.priorityIndex > 2 {
 				m.priorityIndex = 0
 			}
+			m.prioritySet = true
 		}
 		return m, nil
 	}
@@ -276,33 +692,106 @@ func (m Model) updateInputMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch m.inputFocus {
-	case 0:
+	case fieldTitle:
 		m.titleInput, cmd = m.titleInput.Update(msg)
-	case 1:
+	case fieldDesc:
 		m.descInput, cmd = m.descInput.Update(msg)
+	case fieldDue:
+		m.dueInput, cmd = m.dueInput.Update(msg)
+	case fieldEstimate:
+		m.estimateInput, cmd = m.estimateInput.Update(msg)
 	}
 
 	return m, cmd
 }
 
 func (m *Model) updateInputFocus() {
-	switch m.inputFocus {
-	case 0:
-		m.titleInput.Focus()
-		m.titleInput.PromptStyle = ui.FocusedStyle
-		m.descInput.Blur()
-		m.descInput.PromptStyle = ui.BlurredStyle
-	case 1:
-		m.titleInput.Blur()
-		m.titleInput.PromptStyle = ui.BlurredStyle
-		m.descInput.Focus()
-		m.descInput.PromptStyle = ui.FocusedStyle
-	case 2:
-		m.titleInput.Blur()
-		m.titleInput.PromptStyle = ui.BlurredStyle
-		m.descInput.Blur()
-		m.descInput.PromptStyle = ui.BlurredStyle
+	inputs := []*textinput.Model{&m.titleInput, &m.descInput, &m.dueInput, &m.estimateInput}
+	for i, input := range inputs {
+		if i == m.inputFocus {
+			input.Focus()
+			input.PromptStyle = ui.FocusedStyle
+		} else {
+			input.Blur()
+			input.PromptStyle = ui.BlurredStyle
+		}
+	}
+}
+
+func (m *Model) blurInputs() {
+	m.titleInput.Blur()
+	m.descInput.Blur()
+	m.dueInput.Blur()
+	m.estimateInput.Blur()
+}
+
+// formTodo builds a task from the input form. Quick-add tokens in the title
+// fill in anything the other fields leave unset. On error it also returns
+// the field that needs fixing.
+func (m *Model) formTodo() (model.Todo, int, error) {
+	quick, err := model.ParseQuickAdd(m.titleInput.Value(), time.Now())
+	if err != nil {
+		return model.Todo{}, fieldTitle, err
+	}
+	if quick.Text == "" {
+		return model.Todo{}, fieldTitle, fmt.Errorf("empty task text")
+	}
+	due, err := m.parseDueInput()
+	if err != nil {
+		return model.Todo{}, fieldDue, err
+	}
+	estimate, err := m.parseEstimateInput()
+	if err != nil {
+		return model.Todo{}, fieldEstimate, err
+	}
+
+	todo := model.Todo{
+		Text:        quick.Text,
+		Description: m.descInput.Value(),
+		Priority:    model.Priority(m.priorityIndex),
+		Context:     m.cwd, // Set context to current working directory
+		Due:         due,
+		Tags:        quick.Tags,
+		Estimate:    estimate,
+	}
+	if todo.Description == "" {
+		todo.Description = quick.Description
+	}
+	if quick.Priority != nil && !m.prioritySet {
+		todo.Priority = *quick.Priority
+	}
+	if todo.Due == nil {
+		todo.Due = quick.Due
+	}
+	if quick.Global {
+		todo.Context = ""
+	}
+	return todo, 0, nil
+}
+
+// parseDueInput resolves the due field of the input form, nil when empty
+func (m *Model) parseDueInput() (*time.Time, error) {
+	if strings.TrimSpace(m.dueInput.Value()) == "" {
+		return nil, nil
+	}
+	due, err := model.ParseDate(m.dueInput.Value(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &due, nil
+}
+
+// parseEstimateInput resolves the estimate field of the input form to its
+// canonical form, "" when empty
+func (m *Model) parseEstimateInput() (string, error) {
+	if strings.TrimSpace(m.estimateInput.Value()) == "" {
+		return "", nil
+	}
+	estimate, err := model.ParseEstimate(m.estimateInput.Value())
+	if err != nil {
+		return "", err
 	}
+	return estimate.String(), nil
 }
 
 func getCelebrationMessage(total int) string {
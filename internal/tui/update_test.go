@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"upnext/internal/model"
+)
+
+func TestAddFormQuickAdd(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []string
+		text  string
+		prio  model.Priority
+		added bool
+	}{
+		{"tokens", []string{"a", "Ship it !high", "enter", "enter", "enter", "enter", "enter"}, "Ship it", model.PriorityHigh, true},
+		{"form priority wins", []string{"a", "Ship it !high", "enter", "enter", "enter", "enter", "left", "enter"}, "Ship it", model.PriorityLow, true},
+		{"only tokens", []string{"a", "!high due:fri", "enter", "enter", "enter", "enter", "enter"}, "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, s := newTestModel(t)
+			m = press(t, m, tt.keys[:len(tt.keys)-1]...)
+			if preview := m.renderQuickAddPreview(); tt.added && !strings.Contains(preview, tt.prio.String()) {
+				t.Errorf("preview %q doesn't show the priority saved, %v", preview, tt.prio)
+			}
+			m = press(t, m, tt.keys[len(tt.keys)-1])
+			if !tt.added {
+				if len(s.data.Items) != 0 {
+					t.Fatalf("added %+v", s.data.Items)
+				}
+				if m.mode != ModeInput || m.inputFocus != fieldTitle {
+					t.Errorf("form closed or left the title field: mode %d, field %d", m.mode, m.inputFocus)
+				}
+				return
+			}
+			if len(s.data.Items) != 1 {
+				t.Fatalf("added %d tasks, want 1", len(s.data.Items))
+			}
+			if got := s.data.Items[0]; got.Text != tt.text || got.Priority != tt.prio {
+				t.Errorf("added %q at %v, want %q at %v", got.Text, got.Priority, tt.text, tt.prio)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -33,12 +34,37 @@ func (m Model) View() string {
 		sections = append(sections, m.renderCelebration())
 	case ModeHelp:
 		sections = append(sections, m.renderFullHelp())
+	case ModeTagPicker:
+		sections = append(sections, m.renderTagPicker())
 	case ModeInput:
 		sections = append(sections, m.renderTable())
 		sections = append(sections, "")
 		sections = append(sections, m.renderInputForm())
+	case ModeSnooze:
+		sections = append(sections, m.renderTable())
+		sections = append(sections, "")
+		sections = append(sections, m.renderSnoozePrompt())
+	case ModeBulk:
+		sections = append(sections, m.renderTable())
+		sections = append(sections, "")
+		sections = append(sections, m.renderBulkPrompt())
+	case ModeGrab:
+		sections = append(sections, m.renderTable())
+		sections = append(sections, "")
+		sections = append(sections, m.renderGrabHint())
+	case ModeConfirm:
+		if m.focused {
+			// The focus view makes room for the prompt itself
+			sections = append(sections, m.renderFocus())
+			break
+		}
+		sections = append(sections, m.renderTable())
+		sections = append(sections, "")
+		sections = append(sections, m.renderConfirm())
 	default:
-		if m.GetCurrentItems() == 0 {
+		if m.focused {
+			sections = append(sections, m.renderFocus())
+		} else if m.GetCurrentItems() == 0 {
 			sections = append(sections, m.renderEmptyState())
 		} else {
 			sections = append(sections, m.renderTable())
@@ -54,19 +80,27 @@ func (m Model) View() string {
 	// Spacer to push status bar to bottom
 	contentHeight := lipgloss.Height(strings.Join(sections, "\n"))
 	spacerHeight := m.height - contentHeight - 3 // 3 for status bar + help
+	if m.timer != nil {
+		spacerHeight--
+	}
 	if spacerHeight > 0 {
 		sections = append(sections, strings.Repeat("\n", spacerHeight))
 	}
 
+	// Running work timer
+	if m.timer != nil {
+		sections = append(sections, " "+m.timer.View(m.width-2, time.Now()))
+	}
+
 	// Help bar (short version)
-	if m.mode != ModeHelp && m.mode != ModeInput {
+	if !m.focused && (m.mode == ModeNormal || m.mode == ModeCelebration || m.mode == ModeTagPicker) {
 		sections = append(sections, m.renderHelpBar())
 	}
 
 	// Status bar
 	sections = append(sections, m.renderStatusBar())
 
-	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+	return withBell(lipgloss.JoinVertical(lipgloss.Left, sections...), m.ringBell)
 }
 
 func (m Model) renderHeader() string {
@@ -79,19 +113,28 @@ func (m Model) renderHeader() string {
 
 func (m Model) renderTabs() string {
 	// Tab labels
-	activeLabel := fmt.Sprintf(" Active (%d) ", len(m.filteredItems))
-	completedLabel := fmt.Sprintf(" Completed (%d) ", len(m.filteredArchive))
+	labels := []struct {
+		tab   Tab
+		label string
+	}{
+		{TabActive, fmt.Sprintf(" Active (%d) ", len(m.filteredItems))},
+		{TabCompleted, fmt.Sprintf(" Completed (%d) ", len(m.filteredArchive))},
+		{TabSnoozed, fmt.Sprintf(" Snoozed (%d) ", len(m.filteredSnoozed))},
+	}
 
-	var activeTab, completedTab string
-	if m.tab == TabActive {
-		activeTab = ui.TabActiveStyle.Render(activeLabel)
-		completedTab = ui.TabInactiveStyle.Render(completedLabel)
-	} else {
-		activeTab = ui.TabInactiveStyle.Render(activeLabel)
-		completedTab = ui.TabActiveStyle.Render(completedLabel)
+	var rendered []string
+	for i, l := range labels {
+		if i > 0 {
+			rendered = append(rendered, " ")
+		}
+		if m.tab == l.tab {
+			rendered = append(rendered, ui.TabActiveStyle.Render(l.label))
+		} else {
+			rendered = append(rendered, ui.TabInactiveStyle.Render(l.label))
+		}
 	}
 
-	tabs := lipgloss.JoinHorizontal(lipgloss.Bottom, activeTab, " ", completedTab)
+	tabs := lipgloss.JoinHorizontal(lipgloss.Bottom, rendered...)
 
 	// Context indicator
 	var contextInfo string
@@ -100,13 +143,70 @@ func (m Model) renderTabs() string {
 	} else if m.cwd != "" {
 		shortPath := filepath.Base(m.cwd)
 		contextInfo = ui.ContextStyle.Render("  " + ui.IconFolder + " " + shortPath)
+		if m.branch != "" {
+			contextInfo += ui.ContextStyle.Render("  " + ui.IconBranch + " " + m.branch)
+		}
+	}
+	if m.tagFilter != "" {
+		contextInfo += ui.ContextStyle.Render("  #" + m.tagFilter)
+	}
+	if m.tab == TabActive && m.sortMode == SortDue {
+		contextInfo += ui.DimStyle.Render("  ↕ by due date")
 	}
 
 	return tabs + contextInfo
 }
 
+// renderTable draws m.table's rows around its cursor. The bubbles table
+// measures ANSI escape codes as text when truncating cells, which blanks out
+// styled cells such as priority icons, so the rows are laid out here.
 func (m Model) renderTable() string {
-	return m.table.View()
+	cols := tableColumns(m.width, m.tab, m.sources != nil)
+	styles := m.tableStyles
+
+	headers := make([]string, len(cols))
+	for i, col := range cols {
+		headers[i] = styles.Header.Render(fitCell(col.Title, col.Width))
+	}
+	lines := []string{lipgloss.JoinHorizontal(lipgloss.Left, headers...)}
+
+	rows := m.table.Rows()
+	height := m.table.Height()
+	cursor := m.table.Cursor()
+	start := max(0, cursor-height+1)
+	end := min(len(rows), start+height)
+	for i := start; i < end; i++ {
+		cells := make([]string, len(cols))
+		for j, col := range cols {
+			value := ""
+			if j < len(rows[i]) {
+				value = rows[i][j]
+			}
+			if j == 0 && m.tab == TabActive && m.isSelected(i) {
+				value = ui.CursorStyle.Render(ui.IconSelected)
+			}
+			if j == 0 && m.mode == ModeGrab && i == cursor {
+				value = ui.CursorStyle.Render(ui.IconGrab)
+			}
+			cells[j] = styles.Cell.Render(fitCell(value, col.Width))
+		}
+		row := lipgloss.JoinHorizontal(lipgloss.Left, cells...)
+		if i == cursor {
+			row = styles.Selected.Render(row)
+		}
+		lines = append(lines, row)
+	}
+
+	// Keep the table's height steady as rows come and go
+	for i := end - start; i < height; i++ {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fitCell pads or cuts value to exactly width columns
+func fitCell(value string, width int) string {
+	return lipgloss.NewStyle().Width(width).MaxWidth(width).Inline(true).Render(value)
 }
 
 func (m Model) renderEmptyState() string {
@@ -117,9 +217,12 @@ func (m Model) renderEmptyState() string {
     ✦   ·  ✦    ·  ✦    ·
 `
 	var message string
-	if m.tab == TabActive {
+	switch m.tab {
+	case TabActive:
 		message = "Nothing to do! Press 'a' to add a task."
-	} else {
+	case TabSnoozed:
+		message = "No snoozed tasks. Press 'z' on an active task to snooze it."
+	default:
 		message = "No completed tasks yet. Complete some tasks to see them here!"
 	}
 
@@ -150,17 +253,23 @@ func (m Model) renderInputForm() string {
 
 	// Task title field
 	titleLabel := ui.LabelStyle.Render("Task:")
-	if m.inputFocus == 0 {
+	if m.inputFocus == fieldTitle {
 		titleLabel = ui.FocusedStyle.Render("Task:      ")
 	}
 	b.WriteString(titleLabel)
 	b.WriteString(" ")
 	b.WriteString(m.titleInput.View())
+	if preview := m.renderQuickAddPreview(); preview != "" {
+		b.WriteString("\n")
+		b.WriteString(ui.LabelStyle.Render(""))
+		b.WriteString(" ")
+		b.WriteString(preview)
+	}
 	b.WriteString("\n\n")
 
 	// Description field
 	descLabel := ui.LabelStyle.Render("Description:")
-	if m.inputFocus == 1 {
+	if m.inputFocus == fieldDesc {
 		descLabel = ui.FocusedStyle.Render("Description:")
 	}
 	b.WriteString(descLabel)
@@ -168,9 +277,39 @@ func (m Model) renderInputForm() string {
 	b.WriteString(m.descInput.View())
 	b.WriteString("\n\n")
 
+	// Due date field with a preview of the resolved day
+	dueLabel := ui.LabelStyle.Render("Due:")
+	if m.inputFocus == fieldDue {
+		dueLabel = ui.FocusedStyle.Render("Due:        ")
+	}
+	b.WriteString(dueLabel)
+	b.WriteString(" ")
+	b.WriteString(m.dueInput.View())
+	b.WriteString("\n")
+	b.WriteString(ui.LabelStyle.Render(""))
+	b.WriteString(" ")
+	b.WriteString(m.renderDuePreview())
+	b.WriteString("\n\n")
+
+	// Estimate field
+	estLabel := ui.LabelStyle.Render("Estimate:")
+	if m.inputFocus == fieldEstimate {
+		estLabel = ui.FocusedStyle.Render("Estimate:   ")
+	}
+	b.WriteString(estLabel)
+	b.WriteString(" ")
+	b.WriteString(m.estimateInput.View())
+	if _, err := m.parseEstimateInput(); err != nil {
+		b.WriteString("\n")
+		b.WriteString(ui.LabelStyle.Render(""))
+		b.WriteString(" ")
+		b.WriteString(ui.ErrorStyle.Render("✗ " + err.Error()))
+	}
+	b.WriteString("\n\n")
+
 	// Priority selector
 	priLabel := ui.LabelStyle.Render("Priority:")
-	if m.inputFocus == 2 {
+	if m.inputFocus == fieldPriority {
 		priLabel = ui.FocusedStyle.Render("Priority:  ")
 	}
 	b.WriteString(priLabel)
@@ -179,13 +318,66 @@ func (m Model) renderInputForm() string {
 	b.WriteString("\n\n")
 
 	// Help text
-	helpText := ui.DimStyle.Render("tab: next field • enter: submit • esc: cancel")
+	helpText := ui.DimStyle.Render("tab: next field • enter: submit • esc: cancel • quick-add: !high #tag due:fri @global -- desc")
 	b.WriteString(helpText)
 
 	// Wrap in dialog box
 	return ui.DialogStyle.Width(m.width - 10).Render(b.String())
 }
 
+// renderQuickAddPreview shows what the quick-add tokens in the title parse to
+func (m Model) renderQuickAddPreview() string {
+	quick, err := model.ParseQuickAdd(m.titleInput.Value(), time.Now())
+	if err != nil {
+		return ui.ErrorStyle.Render("✗ " + err.Error())
+	}
+	if !quick.HasTokens() {
+		return ""
+	}
+	if quick.Text == "" {
+		return ui.ErrorStyle.Render("✗ empty task text")
+	}
+
+	sep := ui.DimStyle.Render(" · ")
+	parts := []string{ui.TitleStyle.Render(quick.Text)}
+	if quick.Priority != nil {
+		// A priority picked in the form is the one saved
+		priority := *quick.Priority
+		if m.prioritySet {
+			priority = model.Priority(m.priorityIndex)
+		}
+		parts = append(parts, m.priorityIcon(priority)+" "+priority.String())
+	}
+	for _, tag := range quick.Tags {
+		parts = append(parts, ui.ContextStyle.Render("#"+tag))
+	}
+	if quick.Due != nil {
+		parts = append(parts, ui.DueTodayStyle.Render("due "+quick.Due.Format("Mon Jan 2")))
+	}
+	if quick.Global {
+		parts = append(parts, ui.ContextStyle.Render(ui.IconGlobal+" global"))
+	}
+	if quick.Description != "" {
+		parts = append(parts, ui.SubtitleStyle.Render(quick.Description))
+	}
+	return ui.CheckmarkStyle.Render("→ ") + strings.Join(parts, sep)
+}
+
+// renderDuePreview shows the day the due field resolves to
+func (m Model) renderDuePreview() string {
+	if strings.TrimSpace(m.dueInput.Value()) == "" {
+		if quick, err := model.ParseQuickAdd(m.titleInput.Value(), time.Now()); err == nil && quick.Due != nil {
+			return ui.DimStyle.Render("set by due: in the task text")
+		}
+		return ui.DimStyle.Render("no due date")
+	}
+	due, err := m.parseDueInput()
+	if err != nil {
+		return ui.ErrorStyle.Render("✗ " + err.Error())
+	}
+	return ui.CheckmarkStyle.Render("→ ") + ui.SubtitleStyle.Render(due.Format("Mon Jan 2, 2006")+" ("+formatDue(*due, time.Now())+")")
+}
+
 func (m Model) renderPrioritySelector() string {
 	priorities := []struct {
 		label string
@@ -201,7 +393,7 @@ func (m Model) renderPrioritySelector() string {
 		label := p.label
 		if i == m.priorityIndex {
 			// Selected priority
-			if m.inputFocus == 2 {
+			if m.inputFocus == fieldPriority {
 				// Focused on priority selector
 				label = ui.ButtonActiveStyle.Render(label)
 			} else {
@@ -223,14 +415,25 @@ func (m Model) renderHelpBar() string {
 func (m Model) renderStatusBar() string {
 	// Left side: item count based on current tab
 	var itemCount string
-	if m.tab == TabActive {
+	switch m.tab {
+	case TabActive:
 		count := len(m.filteredItems)
 		if count == 1 {
 			itemCount = "1 active task"
 		} else {
 			itemCount = fmt.Sprintf("%d active tasks", count)
 		}
-	} else {
+		if work := formatWork(model.SumEstimates(m.filteredItems)); work != "" {
+			itemCount += "  •  ~" + work + " of work here"
+		}
+	case TabSnoozed:
+		count := len(m.filteredSnoozed)
+		if count == 1 {
+			itemCount = "1 snoozed task"
+		} else {
+			itemCount = fmt.Sprintf("%d snoozed tasks", count)
+		}
+	default:
 		count := len(m.filteredArchive)
 		if count == 1 {
 			itemCount = "1 completed task"
@@ -239,12 +442,33 @@ func (m Model) renderStatusBar() string {
 		}
 	}
 
+	if n := m.SelectionCount(); n > 0 {
+		itemCount += fmt.Sprintf("  •  %d selected", n)
+	}
+	if m.notice != "" {
+		itemCount += "  •  " + m.notice
+	}
+	if m.err != nil {
+		itemCount += "  •  " + ui.ErrorStyle.Render("✗ "+m.err.Error())
+	}
+
+	// Time being tracked with `upnext start`
+	if i, ok := m.data.Tracking(); ok {
+		item := m.data.Items[i]
+		running := item.TimeEntries[len(item.TimeEntries)-1]
+		itemCount += "  •  " + ui.IconTracking + " " + truncateText(item.Text, 30) + " " + model.FormatTracked(running.Duration(time.Now()))
+	}
+
 	// Right side: total completed
 	completed := fmt.Sprintf("🏆 %d total completed", m.data.Stats.TotalCompleted)
 
 	// Calculate padding
 	leftContent := itemCount
 	rightContent := completed
+	if lipgloss.Width(leftContent)+lipgloss.Width(rightContent)+5 > m.width {
+		// The running totals matter more than the trophy
+		rightContent = ""
+	}
 	padding := m.width - lipgloss.Width(leftContent) - lipgloss.Width(rightContent) - 4
 
 	if padding < 1 {
@@ -256,6 +480,18 @@ func (m Model) renderStatusBar() string {
 	return ui.StatusBarStyle.Width(m.width).Render(statusContent)
 }
 
+// formatWork renders summed estimates as "3h 20m", "5pt" or "3h 20m + 5pt"
+func formatWork(sum model.Estimate) string {
+	var parts []string
+	if sum.Minutes > 0 {
+		parts = append(parts, model.FormatTracked(sum.Duration()))
+	}
+	if sum.Points > 0 {
+		parts = append(parts, fmt.Sprintf("%dpt", sum.Points))
+	}
+	return strings.Join(parts, " + ")
+}
+
 func (m Model) renderFullHelp() string {
 	helpItems := []struct {
 		key  string
@@ -264,13 +500,25 @@ func (m Model) renderFullHelp() string {
 		{"↑/k, ↓/j", "Navigate tasks"},
 		{"pgup/^u, pgdn/^d", "Page up/down"},
 		{"g/G", "Go to top/bottom"},
-		{"1/2", "Switch to Active/Completed tab"},
+		{"1/2/3", "Switch to Active/Completed/Snoozed tab"},
 		{"enter/d", "Complete task (Active) / View (Completed)"},
 		{"u", "Uncomplete task (Completed tab)"},
 		{"a", "Add new task"},
 		{"x", "Drop (delete) task"},
+		{"C", "Clear completed tasks (Completed tab)"},
 		{"b", "Bump task to top"},
+		{"K/J", "Move task up/down (also shift+↑/↓)"},
+		{"r", "Grab task to move it; enter drops it"},
+		{"z", "Snooze task (Active) / Wake task (Snoozed)"},
+		{"s", "Show steps; space ticks the selected step"},
+		{"f", "Focus on the next task (s skips it)"},
+		{"t", "Start/stop a work timer on the task"},
+		{"space/V/*", "Select task / range / all for bulk actions"},
+		{"T/p/m", "Retag / set priority / move selected tasks"},
+		{"U", "Undo the last bulk change"},
 		{"A", "Toggle show all tasks"},
+		{"o", "Sort by due date / manual order"},
+		{"#", "Filter by tag"},
 		{"?", "Toggle help"},
 		{"q/esc", "Quit"},
 	}
@@ -301,6 +549,302 @@ func (m Model) renderFullHelp() string {
 	)
 }
 
+func (m Model) renderTagPicker() string {
+	var lines []string
+	lines = append(lines, ui.DialogTitleStyle.Render("# Filter by tag"))
+
+	options := append([]string{"all tasks"}, m.tagOptions...)
+	for i, option := range options {
+		label := option
+		if i > 0 {
+			label = "#" + option
+		}
+		if i == m.tagCursor {
+			lines = append(lines, ui.CursorStyle.Render(ui.IconCursor+" ")+ui.SelectedStyle.Render(label))
+		} else {
+			lines = append(lines, "  "+ui.ItemStyle.Render(label))
+		}
+	}
+	if len(m.tagOptions) == 0 {
+		lines = append(lines, "")
+		lines = append(lines, ui.DimStyle.Render("No tagged tasks here yet. Add one with #tag."))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, ui.DimStyle.Render("enter: apply • esc: cancel"))
+
+	dialog := ui.HelpOverlayStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.Place(
+		m.width,
+		m.height-6,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// renderSteps lists the task's checklist when it is expanded, or
+// summarizes it otherwise
+func (m Model) renderSteps(item model.Todo) []string {
+	summary := "Steps " + model.StepSummary(item.Steps)
+	if m.mode != ModeSteps {
+		return []string{ui.DimStyle.Render(summary + " • press 's' to expand")}
+	}
+
+	lines := []string{ui.LabelStyle.Render(summary)}
+	for i, step := range item.Steps {
+		cursor := "  "
+		if i == m.stepCursor {
+			cursor = ui.CursorStyle.Render(ui.IconCursor + " ")
+		}
+		var text string
+		switch {
+		case step.Done:
+			text = ui.CheckmarkStyle.Render(ui.IconCheckmark) + ui.DimStyle.Render(" "+step.Text)
+		case i == m.stepCursor:
+			text = ui.DimStyle.Render(ui.IconUnchecked) + ui.SelectedStyle.Render(step.Text)
+		default:
+			text = ui.DimStyle.Render(ui.IconUnchecked) + ui.ItemStyle.Render(step.Text)
+		}
+		lines = append(lines, cursor+text)
+	}
+	lines = append(lines, ui.DimStyle.Render("space: toggle • ↑/↓: move • esc: close"))
+	return lines
+}
+
+// renderFocus shows the focused task on its own, with a count of what's left
+func (m Model) renderFocus() string {
+	hint := ui.DimStyle.Render("enter: done • s: skip • t: timer • f: back to list • q: quit")
+
+	item, _, ok := m.focusedTodo()
+	if !ok {
+		content := lipgloss.JoinVertical(lipgloss.Center,
+			ui.CheckmarkStyle.Render(ui.IconCheckmark+" All clear"),
+			"",
+			ui.SubtitleStyle.Render("Nothing left to do here."),
+			"",
+			ui.DimStyle.Render("f: back to list • q: quit"),
+		)
+		return lipgloss.Place(m.width, m.height-8, lipgloss.Center, lipgloss.Center, content)
+	}
+
+	width := min(m.width-10, 72)
+	var lines []string
+	lines = append(lines, ui.DimStyle.Render(fmt.Sprintf("what's next? · %d remaining", len(m.filteredItems))))
+	lines = append(lines, "")
+	lines = append(lines, m.priorityIcon(item.Priority)+" "+ui.TitleStyle.Render(item.Text))
+
+	if item.Description != "" {
+		lines = append(lines, "")
+		lines = append(lines, ui.SubtitleStyle.Copy().Width(width-8).Align(lipgloss.Center).Render(item.Description))
+	}
+
+	var meta []string
+	now := time.Now()
+	if item.Due != nil {
+		due := "due " + formatDue(*item.Due, now)
+		switch {
+		case item.IsOverdue(now):
+			due = ui.OverdueStyle.Render(due)
+		case item.IsDueToday(now):
+			due = ui.DueTodayStyle.Render(due)
+		default:
+			due = ui.DimStyle.Render(due)
+		}
+		meta = append(meta, due)
+	}
+	if len(item.Steps) > 0 {
+		meta = append(meta, ui.DimStyle.Render("steps "+model.StepSummary(item.Steps)))
+	}
+	if len(item.Tags) > 0 {
+		meta = append(meta, ui.ContextStyle.Render(formatTags(item.Tags)))
+	}
+	if m.data.IsBlocked(item) {
+		meta = append(meta, ui.DueTodayStyle.Render(ui.IconBlocked+" blocked"))
+	}
+	if len(meta) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, strings.Join(meta, ui.DimStyle.Render("  •  ")))
+	}
+
+	card := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(ui.BrightViolet).
+		Padding(1, 4).
+		Width(width).
+		Align(lipgloss.Center).
+		Render(strings.Join(lines, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Center, card, "", hint)
+	if m.mode == ModeConfirm {
+		content = lipgloss.JoinVertical(lipgloss.Center, card, "", m.renderConfirm())
+	}
+	return lipgloss.Place(m.width, m.height-8, lipgloss.Center, lipgloss.Center, content)
+}
+
+// renderConfirm asks the user to confirm m.confirmAction
+func (m Model) renderConfirm() string {
+	var b strings.Builder
+	titleWidth := m.width - 30
+
+	switch m.confirmAction {
+	case confirmComplete:
+		item, _ := m.selectedTodo()
+		done, total := item.StepProgress()
+		b.WriteString(ui.DialogTitleStyle.Render("Complete " + truncateText(item.Text, titleWidth) + "?"))
+		b.WriteString("\n\n")
+		b.WriteString(ui.SubtitleStyle.Render(fmt.Sprintf("%d of %d steps are still unfinished.", total-done, total)))
+	case confirmBulkComplete:
+		targets := m.bulkTargets()
+		open := 0
+		for _, item := range targets {
+			if item.HasOpenSteps() {
+				open++
+			}
+		}
+		b.WriteString(ui.DialogTitleStyle.Render(fmt.Sprintf("Complete %d %s?", len(targets), model.Plural(len(targets), "task"))))
+		b.WriteString("\n\n")
+		b.WriteString(m.renderConfirmTargets(targets))
+		if open > 0 {
+			verb := "have"
+			if open == 1 {
+				verb = "has"
+			}
+			b.WriteString("\n\n")
+			b.WriteString(ui.SubtitleStyle.Render(fmt.Sprintf("%d of them still %s unfinished steps.", open, verb)))
+		}
+	case confirmDrop:
+		if item, ok := m.selectedArchived(); ok {
+			b.WriteString(ui.DialogTitleStyle.Render("Delete " + truncateText(item.Text, titleWidth) + " for good?"))
+			b.WriteString("\n\n")
+			b.WriteString(ui.SubtitleStyle.Render("It's removed from the completed list and can't be brought back."))
+			break
+		}
+		item, _ := m.selectedTodo()
+		b.WriteString(ui.DialogTitleStyle.Render("Drop " + truncateText(item.Text, titleWidth) + "?"))
+		b.WriteString("\n\n")
+		b.WriteString(ui.SubtitleStyle.Render("It's deleted without being completed."))
+	case confirmBulkDrop:
+		targets := m.bulkTargets()
+		b.WriteString(ui.DialogTitleStyle.Render(fmt.Sprintf("Drop %d %s?", len(targets), model.Plural(len(targets), "task"))))
+		b.WriteString("\n\n")
+		b.WriteString(m.renderConfirmTargets(targets))
+		b.WriteString("\n\n")
+		b.WriteString(ui.SubtitleStyle.Render("They're deleted without being completed; U undoes it."))
+	case confirmClearArchive:
+		n := len(m.filteredArchive)
+		b.WriteString(ui.DialogTitleStyle.Render(fmt.Sprintf("Clear %d completed %s?", n, model.Plural(n, "task"))))
+		b.WriteString("\n\n")
+		b.WriteString(ui.SubtitleStyle.Render("They're deleted for good. Your completed count stays."))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(ui.DimStyle.Render("y/enter: yes • n/esc: no"))
+
+	return ui.DialogStyle.Width(m.width - 10).Render(b.String())
+}
+
+// renderConfirmTargets lists the first few tasks a bulk action applies to
+func (m Model) renderConfirmTargets(targets []model.Todo) string {
+	const shown = 3
+	var lines []string
+	for i, item := range targets {
+		if i == shown {
+			lines = append(lines, ui.DimStyle.Render(fmt.Sprintf("  … and %d more", len(targets)-shown)))
+			break
+		}
+		lines = append(lines, "  • "+truncateText(item.Text, m.width-30))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderBulkPrompt asks for the change to make to the selected tasks
+func (m Model) renderBulkPrompt() string {
+	var b strings.Builder
+
+	targets := m.bulkTargets()
+	subject := fmt.Sprintf("%d %s", len(targets), model.Plural(len(targets), "task"))
+	if len(targets) == 1 {
+		subject = truncateText(targets[0].Text, m.width-30)
+	}
+
+	label := ""
+	switch m.bulkAction {
+	case bulkTags:
+		b.WriteString(ui.DialogTitleStyle.Render("Retag " + subject))
+		label = "Tags:"
+	case bulkPriority:
+		b.WriteString(ui.DialogTitleStyle.Render("Set priority of " + subject))
+		label = "Priority:"
+	case bulkMove:
+		b.WriteString(ui.DialogTitleStyle.Render(ui.IconFolder + " Move " + subject))
+		label = "To:"
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.FocusedStyle.Render(fmt.Sprintf("%-12s", label)))
+	b.WriteString(" ")
+	b.WriteString(m.bulkInput.View())
+	b.WriteString("\n")
+	b.WriteString(ui.LabelStyle.Render(""))
+	b.WriteString(" ")
+	if strings.TrimSpace(m.bulkInput.Value()) == "" && m.bulkAction != bulkMove {
+		b.WriteString(ui.DimStyle.Render("e.g. " + m.bulkInput.Placeholder))
+	} else if _, _, err := m.bulkChange(m.bulkAction, m.bulkInput.Value()); err != nil {
+		b.WriteString(ui.ErrorStyle.Render("✗ " + err.Error()))
+	} else if m.bulkAction == bulkMove {
+		context, _ := m.resolveContext(m.bulkInput.Value())
+		b.WriteString(ui.CheckmarkStyle.Render("→ ") + ui.ContextStyle.Render(model.GetContextDisplay(context, m.cwd)))
+	} else {
+		b.WriteString(ui.CheckmarkStyle.Render("✓"))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.DimStyle.Render("enter: apply • esc: cancel"))
+
+	return ui.DialogStyle.Width(m.width - 10).Render(b.String())
+}
+
+// renderGrabHint explains how to move the grabbed task
+func (m Model) renderGrabHint() string {
+	title := ui.IconGrab + " Moving task"
+	if item, ok := m.selectedTodo(); ok {
+		title += ": " + truncateText(item.Text, m.width-30)
+	}
+	return ui.DialogTitleStyle.Render(title) + "\n" +
+		ui.DimStyle.Render("↑/k ↓/j: move • g/G: top/bottom • enter: drop • esc: cancel")
+}
+
+// renderSnoozePrompt asks how long to snooze the selected task
+func (m Model) renderSnoozePrompt() string {
+	var b strings.Builder
+
+	title := "💤 Snooze task"
+	if item, ok := m.selectedTodo(); ok {
+		title += ": " + truncateText(item.Text, m.width-30)
+	}
+	b.WriteString(ui.DialogTitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.FocusedStyle.Render("Until:      "))
+	b.WriteString(" ")
+	b.WriteString(m.snoozeInput.View())
+	b.WriteString("\n")
+	b.WriteString(ui.LabelStyle.Render(""))
+	b.WriteString(" ")
+	if strings.TrimSpace(m.snoozeInput.Value()) == "" {
+		b.WriteString(ui.DimStyle.Render("e.g. 2h, 1h30m, tomorrow, mon, in 3d"))
+	} else if until, err := model.ParseWhen(m.snoozeInput.Value(), time.Now()); err != nil {
+		b.WriteString(ui.ErrorStyle.Render("✗ " + err.Error()))
+	} else {
+		b.WriteString(ui.CheckmarkStyle.Render("→ ") + ui.SubtitleStyle.Render(until.Format("Mon Jan 2 15:04")+" ("+formatUntil(until)+")"))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.DimStyle.Render("enter: snooze • esc: cancel"))
+
+	return ui.DialogStyle.Width(m.width - 10).Render(b.String())
+}
+
 func (m Model) renderCelebration() string {
 	celebration := `
     ✨ ⭐ ✨ ⭐ ✨ ⭐ ✨
@@ -324,27 +868,24 @@ func (m Model) renderCelebration() string {
 
 // renderTaskDetails shows expanded details for the selected task
 func (m Model) renderTaskDetails() string {
-	if m.tab == TabActive {
-		return m.renderActiveTaskDetails()
+	if m.tab == TabCompleted {
+		return m.renderCompletedTaskDetails()
 	}
-	return m.renderCompletedTaskDetails()
+	return m.renderActiveTaskDetails()
 }
 
+// renderActiveTaskDetails shows the selected task on the Active or Snoozed tab
 func (m Model) renderActiveTaskDetails() string {
-	if len(m.filteredItems) == 0 {
+	item, ok := m.selectedTodo()
+	if !ok {
 		return ""
 	}
 
 	cursor := m.table.Cursor()
-	if cursor >= len(m.filteredItems) {
-		return ""
-	}
-
-	item := m.filteredItems[cursor]
 	var lines []string
 
 	// Title with task number indicator
-	taskNum := fmt.Sprintf("Task %d of %d", cursor+1, len(m.filteredItems))
+	taskNum := fmt.Sprintf("Task %d of %d", cursor+1, m.GetCurrentItems())
 	headerLine := ui.DimStyle.Render(taskNum)
 	lines = append(lines, headerLine)
 	lines = append(lines, "")
@@ -379,13 +920,75 @@ func (m Model) renderActiveTaskDetails() string {
 	infoLine := priText + "  " + ui.DimStyle.Render("•") + "  " + ageText
 
 	// Context info
-	ctx := model.GetContextDisplay(item.Context, m.cwd)
+	ctx := model.GetContextDisplay(item.ContextIn(m.checkout), m.cwd)
 	if ctx != "" {
 		infoLine += "  " + ui.DimStyle.Render("•") + "  " + ui.ContextStyle.Render(ctx)
 	}
 
 	lines = append(lines, infoLine)
 
+	if len(item.Tags) > 0 {
+		lines = append(lines, ui.ContextStyle.Render(formatTags(item.Tags)))
+	}
+
+	if item.Repeat != "" {
+		if r, err := model.ParseRecurrence(item.Repeat); err == nil {
+			lines = append(lines, ui.DimStyle.Render(ui.IconRepeat+" Repeats "+r.String()))
+		}
+	}
+
+	// Due and scheduled dates
+	if item.Due != nil {
+		now := time.Now()
+		dueText := "Due: " + item.Due.Format("Mon Jan 2") + " (" + formatDue(*item.Due, now) + ")"
+		switch {
+		case item.IsOverdue(now):
+			dueText = ui.OverdueStyle.Render(dueText)
+		case item.IsDueToday(now):
+			dueText = ui.DueTodayStyle.Render(dueText)
+		default:
+			dueText = ui.DimStyle.Render(dueText)
+		}
+		lines = append(lines, dueText)
+	}
+
+	if blockers := m.data.Blockers(item); len(blockers) > 0 {
+		lines = append(lines, ui.DueTodayStyle.Render(ui.IconBlocked+" Blocked by: "+model.JoinTexts(blockers)))
+	}
+	if dependents := m.data.Dependents(item.ID); len(dependents) > 0 {
+		lines = append(lines, ui.DimStyle.Render("Blocks: "+model.JoinTexts(dependents)))
+	}
+
+	if item.Estimate != "" {
+		lines = append(lines, ui.DimStyle.Render("Estimated "+item.Estimate))
+	}
+	if len(item.TimeEntries) > 0 {
+		tracked := ui.IconTracking + " Tracked " + model.FormatTracked(item.TrackedTime(time.Now()))
+		if item.IsTracking() {
+			tracked += " (running)"
+		}
+		lines = append(lines, ui.DimStyle.Render(tracked))
+	}
+	if n := len(item.Pomodoros); n > 0 {
+		sessions := fmt.Sprintf("🍅 %d work sessions", n)
+		if n == 1 {
+			sessions = "🍅 1 work session"
+		}
+		lines = append(lines, ui.DimStyle.Render(sessions))
+	}
+
+	if len(item.Steps) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, m.renderSteps(item)...)
+	}
+
+	if item.SnoozedUntil != nil {
+		until := "Snoozed until " + item.SnoozedUntil.Format("Mon Jan 2 15:04") + " (" + formatUntil(*item.SnoozedUntil) + ")"
+		lines = append(lines, ui.DimStyle.Render(until))
+		lines = append(lines, "")
+		lines = append(lines, ui.DimStyle.Render("Press 'z' to wake it now"))
+	}
+
 	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
 
 	// Create a styled panel
@@ -438,13 +1041,24 @@ func (m Model) renderCompletedTaskDetails() string {
 	infoLine := completedText + "  " + ui.DimStyle.Render("•") + "  " + createdText
 
 	// Context info
-	ctx := model.GetContextDisplay(item.Context, m.cwd)
+	ctx := model.GetContextDisplay(item.ContextIn(m.checkout), m.cwd)
 	if ctx != "" {
 		infoLine += "  " + ui.DimStyle.Render("•") + "  " + ui.ContextStyle.Render(ctx)
 	}
 
 	lines = append(lines, infoLine)
 
+	if len(item.Tags) > 0 {
+		lines = append(lines, ui.ContextStyle.Render(formatTags(item.Tags)))
+	}
+
+	if item.Estimate != "" {
+		lines = append(lines, ui.DimStyle.Render("Estimated "+item.Estimate))
+	}
+	if tracked := item.TrackedTime(); tracked > 0 {
+		lines = append(lines, ui.DimStyle.Render(ui.IconTracking+" Tracked "+model.FormatTracked(tracked)))
+	}
+
 	// Hint about uncomplete
 	lines = append(lines, "")
 	lines = append(lines, ui.DimStyle.Render("Press 'u' to move back to active tasks"))
@@ -186,6 +186,18 @@ var (
 	PriorityLowStyle = lipgloss.NewStyle().
 				Foreground(SkyBlue)
 
+	// Due date styles
+	OverdueStyle = lipgloss.NewStyle().
+			Foreground(Red).
+			Bold(true)
+
+	DueTodayStyle = lipgloss.NewStyle().
+			Foreground(Peach)
+
+	// Inline validation errors
+	ErrorStyle = lipgloss.NewStyle().
+			Foreground(Red)
+
 	// Dialog/modal styles
 	DialogStyle = lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
@@ -251,6 +263,13 @@ const (
 	IconLow       = "▽"
 	IconFolder    = "📁"
 	IconGlobal    = "🌐"
+	IconRepeat    = "↻"
+	IconSnoozed   = "z"
+	IconBlocked   = "⛔"
+	IconTracking  = "⏱"
+	IconSelected  = "◉"
+	IconGrab      = "↕"
+	IconBranch    = "⎇"
 )
 
 // RenderProgressBar creates a gradient progress bar